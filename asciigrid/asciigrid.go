@@ -3,10 +3,12 @@
 package asciigrid
 
 import (
-	"bytes"
 	"fmt"
 	"iter"
+	"slices"
 	"strconv"
+	"strings"
+	"unsafe"
 )
 
 // Direction represents the vertical, horizontal, and diagonal directions in the
@@ -109,7 +111,48 @@ func (d Direction) String() string {
 //
 //	p.Step(d).Step(d.Inverse()) == p
 func (d Direction) Inverse() Direction {
-	return d.Turn(TurnAround)
+	if uint(d) >= uint(len(inverses)) {
+		return d.Turn(TurnAround)
+	}
+	return inverses[d]
+}
+
+// inverses holds the inverse of each direction, as it's used in hot loops
+// where turning around one 45-degree step at a time is too slow.
+var inverses = [...]Direction{
+	None:        None,
+	Up:          Down,
+	Down:        Up,
+	Left:        Right,
+	Right:       Left,
+	TopLeft:     BottomRight,
+	TopRight:    BottomLeft,
+	BottomLeft:  TopRight,
+	BottomRight: TopLeft,
+}
+
+// deltas holds the change in row and column from taking a single step in each
+// direction.
+var deltas = [...]struct{ dRow, dCol int }{
+	None:        {0, 0},
+	Up:          {-1, 0},
+	Down:        {+1, 0},
+	Left:        {0, -1},
+	Right:       {0, +1},
+	TopLeft:     {-1, -1},
+	TopRight:    {-1, +1},
+	BottomLeft:  {+1, -1},
+	BottomRight: {+1, +1},
+}
+
+// delta returns the change in row and column from taking a single step in
+// direction d.
+func (d Direction) delta() (dRow, dCol int) {
+	if uint(d) >= uint(len(deltas)) {
+		return 0, 0
+	}
+	delta := deltas[d]
+	return delta.dRow, delta.dCol
 }
 
 const (
@@ -205,6 +248,12 @@ type Pos struct {
 	Row, Col int
 }
 
+// XY returns the column and row of p as X and Y coordinates, with Y pointing
+// down. It makes Pos usable with the polygon functions in the geometry package.
+func (p Pos) XY() (x, y int) {
+	return p.Col, p.Row
+}
+
 // Step returns the position a single step in the given direction.
 func (p Pos) Step(d Direction) Pos {
 	return p.StepN(d, 1)
@@ -212,32 +261,11 @@ func (p Pos) Step(d Direction) Pos {
 
 // StepN is like Step but takes n steps in the given direction.
 func (p Pos) StepN(d Direction, n int) Pos {
-	p2 := p
-	switch d {
-	case None:
-		// Nothing happens.
-	case Up:
-		p2.Row -= n
-	case Down:
-		p2.Row += n
-	case Left:
-		p2.Col -= n
-	case Right:
-		p2.Col += n
-	case TopLeft:
-		p2.Row -= n
-		p2.Col -= n
-	case TopRight:
-		p2.Row -= n
-		p2.Col += n
-	case BottomLeft:
-		p2.Row += n
-		p2.Col -= n
-	case BottomRight:
-		p2.Row += n
-		p2.Col += n
+	dRow, dCol := d.delta()
+	return Pos{
+		Row: p.Row + n*dRow,
+		Col: p.Col + n*dCol,
 	}
-	return p2
 }
 
 // Neighbors4 returns the four direct neighbors (marked 'O' below) to the given
@@ -286,20 +314,95 @@ func (p Pos) Neighbors8() map[Direction]Pos {
 	}
 }
 
-// Grid is a 2D grid of ASCII characters.
-type Grid struct {
-	rows [][]byte
+// neighbors4 and neighbors8 hold the directions to the neighbors of a position,
+// in the order they are yielded by the iterators below: clockwise, starting
+// with Up.
+var (
+	neighbors4 = [...]Direction{Up, Right, Down, Left}
+	neighbors8 = [...]Direction{Up, TopRight, Right, BottomRight, Down, BottomLeft, Left, TopLeft}
+)
+
+// Neighbors4Seq is like Neighbors4 but iterates over the neighbors instead of
+// allocating a map. The neighbors are yielded in a fixed order: clockwise,
+// starting with Up.
+func (p Pos) Neighbors4Seq() iter.Seq2[Direction, Pos] {
+	return func(yield func(Direction, Pos) bool) {
+		for _, d := range neighbors4 {
+			if !yield(d, p.Step(d)) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors8Seq is like Neighbors8 but iterates over the neighbors instead of
+// allocating a map. The neighbors are yielded in a fixed order: clockwise,
+// starting with Up.
+func (p Pos) Neighbors8Seq() iter.Seq2[Direction, Pos] {
+	return func(yield func(Direction, Pos) bool) {
+		for _, d := range neighbors8 {
+			if !yield(d, p.Step(d)) {
+				return
+			}
+		}
+	}
+}
+
+// GridOf is a 2D grid where each cell holds a value of type T. It is useful
+// for puzzles where the cells hold something other than ASCII characters, such
+// as heights, distances or costs. Use [New] to parse a grid of ASCII
+// characters, and [Make] or [Map] to create grids of other types.
+//
+// The cells are stored in a single slice in row-major order. A cell can be
+// addressed either by its [Pos] or by its [Index] within that slice; the latter
+// avoids converting back and forth between positions and offsets in hot loops.
+type GridOf[T any] struct {
+	// cells holds all cells in row-major order. The cell at (row, col) is
+	// stored at cells[row*stride+col].
+	cells []T
+	// stride is the distance in cells between two vertically adjacent cells.
+	// It is at least nCols. Grids parsed by New have stride nCols+1, because
+	// cells then aliases the input including the newline after each row.
+	stride int
+	// shared reports whether cells is referenced by something that must not
+	// be modified, such as the string a Grid was parsed from. If it is true,
+	// cells is copied before the first write.
+	shared bool
 
 	nRows, nCols int
 }
 
+// Grid is a 2D grid of ASCII characters. It is the specialization of GridOf
+// that puzzle inputs are parsed into.
+type Grid = GridOf[byte]
+
 // New parses the given string into a Grid. New assumes that the number of
 // columns in the first row determines the number of columns in all rows, and
-// returns an error if a row with a different number of columns is found.
-func New(s string) (*Grid, error) {
-	bs := bytes.TrimSpace([]byte(s))
+// returns an error if a row with a different number of columns is found. The
+// options change how the input is parsed; see CRLF and PadRaggedRows.
+//
+// New does not copy s unless an option requires it. The grid refers to the
+// bytes of s until the first time it is modified, at which point it makes a
+// copy of its own.
+func New(s string, opts ...Option) (*Grid, error) {
+	g, err := parse(s, newOptions(opts))
+	if err != nil {
+		return nil, fmt.Errorf("asciigrid: %v", err)
+	}
+	return g, nil
+}
+
+// parse implements New.
+func parse(s string, o options) (*Grid, error) {
+	if o.crlf && strings.IndexByte(s, '\r') != -1 {
+		s = strings.ReplaceAll(s, "\r\n", "\n")
+	}
+	if o.pad {
+		return parsePadded(s, o.padWith), nil
+	}
+	s = strings.TrimSpace(s)
 	g := &Grid{}
-	if len(bs) == 0 {
+	if len(s) == 0 {
 		// Special case: this is a completely empty grid, which is valid.
 		// Setting these fields to 0 is redundant, as their values already are
 		// 0, but it helps readability a bit.
@@ -307,102 +410,323 @@ func New(s string) (*Grid, error) {
 		g.nCols = 0
 		return g, nil
 	}
-	g.nCols = bytes.IndexByte(bs, '\n')
+	g.cells = unsafe.Slice(unsafe.StringData(s), len(s))
+	g.shared = true
+	g.nCols = strings.IndexByte(s, '\n')
 	if g.nCols == -1 {
 		// Special case: this is a grid with a single line, which is valid.
-		g.rows = [][]byte{bs}
 		g.nRows = 1
-		g.nCols = len(bs)
+		g.nCols = len(s)
+		g.stride = len(s)
 		return g, nil
 	}
-	for i := 0; i < len(bs); i += g.nCols + 1 {
-		rest := bs[i:]
-		newline := bytes.IndexByte(rest, '\n')
+	g.stride = g.nCols + 1
+	for i := 0; i < len(s); i += g.stride {
+		rest := s[i:]
+		newline := strings.IndexByte(rest, '\n')
 		if newline == -1 {
 			newline = len(rest)
 		}
-		row := rest[:newline]
-		if rowLen := len(row); rowLen != g.nCols {
-			return nil, fmt.Errorf("asciigrid: row %d has %d columns, expected %d", g.nRows, rowLen, g.nCols)
+		if rowLen := newline; rowLen != g.nCols {
+			return nil, fmt.Errorf("row %d has %d columns, expected %d", g.nRows, rowLen, g.nCols)
 		}
-		g.rows = append(g.rows, row)
 		g.nRows++
 	}
 	return g, nil
 }
 
+// parsePadded parses s into a grid as wide as its longest row, where shorter
+// rows are padded with fill at the end. Spaces at the start and end of rows
+// are kept, as they are part of the grid.
+func parsePadded(s string, fill byte) *Grid {
+	s = strings.Trim(s, "\n")
+	if len(s) == 0 {
+		return &Grid{}
+	}
+	lines := strings.Split(s, "\n")
+	nCols := 0
+	for _, line := range lines {
+		nCols = max(nCols, len(line))
+	}
+	// Use the same layout as a parsed grid, with room for a newline at the end
+	// of every row but the last.
+	g := &Grid{
+		cells:  make([]byte, len(lines)*(nCols+1)-1),
+		stride: nCols + 1,
+		nRows:  len(lines),
+		nCols:  nCols,
+	}
+	for row, line := range lines {
+		start := row * g.stride
+		n := copy(g.cells[start:start+nCols], line)
+		for i := start + n; i < start+nCols; i++ {
+			g.cells[i] = fill
+		}
+	}
+	g.fixPadding()
+	return g
+}
+
 // MustNew is like New but panics on error.
-func MustNew(s string) *Grid {
-	g, err := New(s)
+func MustNew(s string, opts ...Option) *Grid {
+	g, err := New(s, opts...)
 	if err != nil {
 		panic(err)
 	}
 	return g
 }
 
+// Make creates a grid with the given number of rows and columns, where every
+// cell holds fill. Make panics if rows or cols is negative.
+func Make[T any](rows, cols int, fill T) *GridOf[T] {
+	if rows < 0 || cols < 0 {
+		panic(fmt.Errorf("asciigrid: Make(%d, %d) has negative dimensions", rows, cols))
+	}
+	g := &GridOf[T]{
+		cells:  make([]T, rows*cols),
+		stride: cols,
+		nRows:  rows,
+		nCols:  cols,
+	}
+	for i := range g.cells {
+		g.cells[i] = fill
+	}
+	return g
+}
+
+// MakeLike creates a grid with the same dimensions and layout as g, where every
+// cell holds fill. Indices from g are valid in the returned grid and vice
+// versa, which makes it suitable for bookkeeping such as "seen" markers.
+func MakeLike[T, S any](g *GridOf[S], fill T) *GridOf[T] {
+	g2 := &GridOf[T]{
+		cells:  make([]T, len(g.cells)),
+		stride: g.stride,
+		nRows:  g.nRows,
+		nCols:  g.nCols,
+	}
+	for i := range g2.cells {
+		g2.cells[i] = fill
+	}
+	g2.fixPadding()
+	return g2
+}
+
+// Map creates a grid with the same dimensions and layout as g, where every cell
+// holds the result of calling f on the corresponding cell in g. A typical use is
+// to turn a grid of digits into a grid of numbers:
+//
+//	heights := asciigrid.Map(g, func(b byte) int { return int(b - '0') })
+//
+// Indices from g are valid in the returned grid and vice versa.
+func Map[S, T any](g *GridOf[S], f func(S) T) *GridOf[T] {
+	g2 := &GridOf[T]{
+		cells:  make([]T, len(g.cells)),
+		stride: g.stride,
+		nRows:  g.nRows,
+		nCols:  g.nCols,
+	}
+	for row := 0; row < g.nRows; row++ {
+		start := row * g.stride
+		for i := start; i < start+g.nCols; i++ {
+			g2.cells[i] = f(g.cells[i])
+		}
+	}
+	g2.fixPadding()
+	return g2
+}
+
+// fixPadding puts newlines in the padding at the end of each row of a byte grid
+// with the same layout as a parsed grid, so that its cells can be used as its
+// string representation. It does nothing for other grids.
+func (g *GridOf[T]) fixPadding() {
+	cells, ok := any(g.cells).([]byte)
+	if !ok || g.stride != g.nCols+1 {
+		return
+	}
+	for i := g.nCols; i < len(cells); i += g.stride {
+		cells[i] = '\n'
+	}
+}
+
+// Clone returns a copy of g. Modifying the copy does not affect g, and vice
+// versa. Cloning a grid that has not been modified since it was parsed by New
+// is free, as both grids refer to the same input until they are modified.
+func (g *GridOf[T]) Clone() *GridOf[T] {
+	g2 := *g
+	if !g.shared {
+		g2.cells = slices.Clone(g.cells)
+	}
+	return &g2
+}
+
 // NRows is the number of rows in the grid.
-func (g *Grid) NRows() int {
+func (g *GridOf[T]) NRows() int {
 	return g.nRows
 }
 
 // NCols is the number of columns in the grid.
-func (g *Grid) NCols() int {
+func (g *GridOf[T]) NCols() int {
 	return g.nCols
 }
 
 // Index represents a position within a grid as a single integer. Use
-// (*Grid).Index to construct these values and (*Grid).Pos to convert them back
-// to positions.
+// (*GridOf).Index to construct these values and (*GridOf).Pos to convert them
+// back to positions.
+//
+// An Index is an offset into the grid's storage, so it can be used to access
+// cells directly with (*GridOf).GetIndex and (*GridOf).SetIndex. Indices are
+// only interchangeable between grids with the same layout, such as a grid and
+// the grids created from it by Clone, Map and MakeLike.
 type Index int
 
 // Index returns the index which corresponds to p. The return value of Index(p) is
 // only valid if InBounds(p) == true.
-func (g *Grid) Index(p Pos) Index {
-	// For each full row, skip over all the columns plus the newline at the end.
-	// Then, skip to the right column.
-	return Index(p.Row*g.nCols + p.Col)
+func (g *GridOf[T]) Index(p Pos) Index {
+	return Index(p.Row*g.stride + p.Col)
 }
 
 // Pos converts the given index, assumed to be created by g.Index, into the
 // corresponding position.
-func (g *Grid) Pos(i Index) Pos {
+func (g *GridOf[T]) Pos(i Index) Pos {
 	return Pos{
-		Row: int(i) / g.nCols,
-		Col: int(i) % g.nCols,
+		Row: int(i) / g.stride,
+		Col: int(i) % g.stride,
+	}
+}
+
+// NIndices returns an upper bound for the indices in the grid: all valid
+// indices are in the range [0, NIndices()). It is suitable as the length of a
+// slice indexed by Index. Note that not every index in that range is valid,
+// since rows may be padded.
+func (g *GridOf[T]) NIndices() int {
+	return len(g.cells)
+}
+
+// StepIndex returns the index a single step in the given direction from i, and
+// reports whether that index is within the bounds of the grid. It is the
+// index-based equivalent of:
+//
+//	p := g.Pos(i).Step(d)
+//	return g.Index(p), g.InBounds(p)
+//
+// but avoids the conversions between indices and positions.
+func (g *GridOf[T]) StepIndex(i Index, d Direction) (Index, bool) {
+	dRow, dCol := d.delta()
+	j := int(i) + dRow*g.stride + dCol
+	if uint(j) >= uint(len(g.cells)) {
+		return -1, false
+	}
+	// Vertical steps can't leave the grid sideways, so the (relatively
+	// expensive) column check is only needed for the other directions.
+	if dCol != 0 {
+		if col := int(i)%g.stride + dCol; uint(col) >= uint(g.nCols) {
+			return -1, false
+		}
+	}
+	return Index(j), true
+}
+
+// Get returns the value at the given position in the grid. Get panics if p is
+// out of bounds.
+func (g *GridOf[T]) Get(p Pos) T {
+	return g.cells[g.checkedIndex(p)]
+}
+
+// GetIndex returns the value at the given index. The index must be valid, as
+// returned by g.Index for a position in bounds or by g.StepIndex.
+func (g *GridOf[T]) GetIndex(i Index) T {
+	return g.cells[i]
+}
+
+// Set stores the given value at the given position. Set panics if p is out of
+// bounds.
+func (g *GridOf[T]) Set(p Pos, v T) {
+	g.SetIndex(g.checkedIndex(p), v)
+}
+
+// SetIndex stores the given value at the given index. The index must be valid,
+// as returned by g.Index for a position in bounds or by g.StepIndex.
+func (g *GridOf[T]) SetIndex(i Index, v T) {
+	if g.shared {
+		g.cells = slices.Clone(g.cells)
+		g.shared = false
+	}
+	g.cells[i] = v
+}
+
+// checkedIndex is like Index but panics if p is out of bounds. Only the column
+// needs to be checked explicitly: a row out of bounds results in an index out of
+// range for cells.
+func (g *GridOf[T]) checkedIndex(p Pos) Index {
+	if uint(p.Col) >= uint(g.nCols) {
+		panic(outOfBounds{p: p, nRows: g.nRows, nCols: g.nCols})
 	}
+	return Index(p.Row*g.stride + p.Col)
 }
 
-// Get returns the ASCII character at the given position in the grid. Get panics
-// if p is out of bounds.
-func (g *Grid) Get(p Pos) byte {
-	return g.rows[p.Row][p.Col]
+// outOfBounds is the value that methods panic with when given a position that is
+// out of bounds.
+type outOfBounds struct {
+	p            Pos
+	nRows, nCols int
 }
 
-// Set stores the given ASCII character at the given position. Set panics if p
-// is out of bounds.
-func (g *Grid) Set(p Pos, b byte) {
-	g.rows[p.Row][p.Col] = b
+func (e outOfBounds) Error() string {
+	return fmt.Sprintf("asciigrid: %v is out of bounds in a grid with %d rows and %d columns", e.p, e.nRows, e.nCols)
 }
 
 // InBounds reports whether the given position is valid in the grid.
-func (g *Grid) InBounds(p Pos) bool {
-	return p.Row >= 0 && p.Row < g.NRows() && p.Col >= 0 && p.Col < g.NCols()
+func (g *GridOf[T]) InBounds(p Pos) bool {
+	return uint(p.Row) < uint(g.nRows) && uint(p.Col) < uint(g.nCols)
 }
 
-// String returns the string (including newlines) that the Grid currently
-// represents.
-func (g *Grid) String() string {
-	return string(bytes.Join(g.rows, []byte{'\n'}))
+// String returns the string (including newlines) that the grid currently
+// represents. For a Grid, this is the ASCII characters themselves. For other
+// types, each cell is formatted with the %v verb and cells are separated by
+// spaces.
+func (g *GridOf[T]) String() string {
+	var sb strings.Builder
+	if cells, ok := any(g.cells).([]byte); ok {
+		if g.stride == g.nCols+1 {
+			// The newlines are already in place, so the cells are the string.
+			if g.shared {
+				return unsafe.String(unsafe.SliceData(cells), len(cells))
+			}
+			return string(cells)
+		}
+		sb.Grow(g.nRows * (g.nCols + 1))
+		for row := 0; row < g.nRows; row++ {
+			if row > 0 {
+				sb.WriteByte('\n')
+			}
+			start := row * g.stride
+			sb.Write(cells[start : start+g.nCols])
+		}
+		return sb.String()
+	}
+	for row := 0; row < g.nRows; row++ {
+		if row > 0 {
+			sb.WriteByte('\n')
+		}
+		start := row * g.stride
+		for i, v := range g.cells[start : start+g.nCols] {
+			if i > 0 {
+				sb.WriteByte(' ')
+			}
+			fmt.Fprint(&sb, v)
+		}
+	}
+	return sb.String()
 }
 
-// All iterates over all positions and the byte stored at them, in an undefined
-// order.
-func (g *Grid) All() iter.Seq2[Pos, byte] {
-	return func(yield func(Pos, byte) bool) {
-		for row := 0; row < g.NRows(); row++ {
-			for col := 0; col < g.NCols(); col++ {
-				p := Pos{Row: row, Col: col}
-				if !yield(p, g.Get(p)) {
+// All iterates over all positions and the value stored at them, in an
+// undefined order.
+func (g *GridOf[T]) All() iter.Seq2[Pos, T] {
+	return func(yield func(Pos, T) bool) {
+		for row := 0; row < g.nRows; row++ {
+			start := row * g.stride
+			for col, v := range g.cells[start : start+g.nCols] {
+				if !yield(Pos{Row: row, Col: col}, v) {
 					return
 				}
 			}
@@ -410,9 +734,37 @@ func (g *Grid) All() iter.Seq2[Pos, byte] {
 	}
 }
 
+// Neighbors4 iterates over the direct neighbors of p that are in bounds of the
+// grid, in the same order as p.Neighbors4Seq().
+func (g *GridOf[T]) Neighbors4(p Pos) iter.Seq2[Direction, Pos] {
+	return func(yield func(Direction, Pos) bool) {
+		for _, d := range neighbors4 {
+			if n := p.Step(d); g.InBounds(n) && !yield(d, n) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors8 iterates over the direct and diagonal neighbors of p that are in
+// bounds of the grid, in the same order as p.Neighbors8Seq().
+func (g *GridOf[T]) Neighbors8(p Pos) iter.Seq2[Direction, Pos] {
+	return func(yield func(Direction, Pos) bool) {
+		for _, d := range neighbors8 {
+			if n := p.Step(d); g.InBounds(n) && !yield(d, n) {
+				return
+			}
+		}
+	}
+}
+
 // Iter represents an iterator over bytes in a string. It is intended to be very
 // similar to the proposed Iter[E any] interface from
 // https://github.com/golang/go/discussions/54245.
+//
+// Deprecated: Iter predates range-over-func iterators. Use the iter.Seq2
+// iterators returned by Rows, Cols, Ray and friends instead, or adapt an Iter
+// with Seq.
 type Iter interface {
 	// Next returns the next byte in the iteration if there is one, and reports
 	// whether the returned value is valid. Once Next returns ok==false, the
@@ -420,75 +772,94 @@ type Iter interface {
 	Next() (p Pos, b byte, ok bool)
 }
 
-// RowIter is an Iter over a single row in the grid. It iterates from left to
-// right.
-type RowIter struct {
-	// row is the row number from which the next byte will be returned from
+// Seq adapts it into a range-over-func iterator. Ranging over the returned
+// iterator consumes it.
+func Seq(it Iter) iter.Seq2[Pos, byte] {
+	return func(yield func(Pos, byte) bool) {
+		for p, b, ok := it.Next(); ok; p, b, ok = it.Next() {
+			if !yield(p, b) {
+				return
+			}
+		}
+	}
+}
+
+// RowIter is an iterator over a single row in the grid. It iterates from left
+// to right. A RowIter over a Grid implements Iter.
+type RowIter[T any] struct {
+	// row is the row number from which the next value will be returned from
 	// Next.
 	row int
-	// col is the column number in the row the next byte will be returned. If
+	// col is the column number in the row the next value will be returned. If
 	// col >= g.NCols() then all subsequent calls to Next return ok==false.
 	col int
-	// g is the grid from which bytes will be returned.
-	g *Grid
+	// g is the grid from which values will be returned.
+	g *GridOf[T]
 }
 
-var _ Iter = (*RowIter)(nil)
+var _ Iter = (*RowIter[byte])(nil)
 
-func (i *RowIter) Next() (Pos, byte, bool) {
+func (i *RowIter[T]) Next() (Pos, T, bool) {
 	if i.col >= i.g.NCols() {
-		return Pos{}, 0, false
+		var zero T
+		return Pos{}, zero, false
 	}
 	p := Pos{Row: i.row, Col: i.col}
-	b := i.g.Get(p)
+	v := i.g.Get(p)
 	i.col++
-	return p, b, true
+	return p, v, true
 }
 
 // Row returns an iterator over the given row. Row panics if row is out of bounds.
-func (g *Grid) Row(row int) *RowIter {
+//
+// Deprecated: Use g.Ray(Pos{Row: row, Col: 0}, Right) or Rows instead.
+func (g *GridOf[T]) Row(row int) *RowIter[T] {
 	if row < 0 || row > g.NRows() {
 		panic(fmt.Errorf("asciigrid: Row(%d) is out of bounds in a grid with %d row", row, g.nRows))
 	}
-	return &RowIter{
+	return &RowIter[T]{
 		row: row,
 		col: 0,
 		g:   g,
 	}
 }
 
-// ColIter is an Iter over a column in the grid. It iterates from top to bottom.
-type ColIter struct {
-	// row is the row number from which the next byte will be returned from
+// ColIter is an iterator over a column in the grid. It iterates from top to
+// bottom. A ColIter over a Grid implements Iter.
+type ColIter[T any] struct {
+	// row is the row number from which the next value will be returned from
 	// Next. If row >= g.NRows() then all subsequent calls to Next return
 	// ok==false.
 	row int
-	// col is the column number in the row from which the next byte will be
+	// col is the column number in the row from which the next value will be
 	// returned.
 	col int
-	// g is the grid from which bytes will be returned.
-	g *Grid
+	// g is the grid from which values will be returned.
+	g *GridOf[T]
 }
 
-var _ Iter = (*ColIter)(nil)
+var _ Iter = (*ColIter[byte])(nil)
 
-func (i *ColIter) Next() (Pos, byte, bool) {
+func (i *ColIter[T]) Next() (Pos, T, bool) {
 	if i.row >= i.g.NRows() {
-		return Pos{}, 0, false
+		var zero T
+		return Pos{}, zero, false
 	}
 	p := Pos{Row: i.row, Col: i.col}
-	b := i.g.Get(p)
+	v := i.g.Get(p)
 	i.row++
-	return p, b, true
+	return p, v, true
 }
 
 // Col returns an iterator over the given column. Col panics if col is out of
 // bounds.
-func (g *Grid) Col(col int) *ColIter {
+//
+// Deprecated: Use g.Ray(Pos{Row: 0, Col: col}, Down) or Cols instead.
+func (g *GridOf[T]) Col(col int) *ColIter[T] {
 	if col < 0 || col >= g.NCols() {
 		panic(fmt.Errorf("asciigrid: Col(%d) is out of bounds in a grid with %d columns", col, g.NCols()))
 	}
-	return &ColIter{
+	return &ColIter[T]{
 		row: 0,
 		col: col,
 		g:   g,
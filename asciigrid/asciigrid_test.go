@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"go.saser.se/adventofgo/aocdata"
 )
 
 func newT(t *testing.T, s string) *Grid {
@@ -268,3 +269,395 @@ abcde
 		t.Errorf("Collecting all bytes gave an unexpected result (-want +got)\n%v", diff)
 	}
 }
+
+func TestMake(t *testing.T) {
+	for _, tt := range []struct {
+		rows, cols int
+		fill       int
+		want       string
+	}{
+		{
+			rows: 0,
+			cols: 0,
+			fill: 1,
+			want: "",
+		},
+		{
+			rows: 1,
+			cols: 3,
+			fill: 7,
+			want: "7 7 7",
+		},
+		{
+			rows: 3,
+			cols: 2,
+			fill: -1,
+			want: "-1 -1\n-1 -1\n-1 -1",
+		},
+	} {
+		g := Make(tt.rows, tt.cols, tt.fill)
+		if got, want := g.NRows(), tt.rows; got != want {
+			t.Errorf("Make(%d, %d, %d).NRows() = %v; want %v", tt.rows, tt.cols, tt.fill, got, want)
+		}
+		if got, want := g.NCols(), tt.cols; got != want {
+			t.Errorf("Make(%d, %d, %d).NCols() = %v; want %v", tt.rows, tt.cols, tt.fill, got, want)
+		}
+		if got, want := g.String(), tt.want; got != want {
+			t.Errorf("Make(%d, %d, %d).String() = %q; want %q", tt.rows, tt.cols, tt.fill, got, want)
+		}
+	}
+}
+
+func TestMake_Set(t *testing.T) {
+	// Rows share an underlying slice, so make sure that setting a value in one
+	// row doesn't spill over into another.
+	g := Make(3, 3, 0)
+	for p := range g.All() {
+		g.Set(p, int(g.Index(p)))
+	}
+	for p, v := range g.All() {
+		if got, want := v, int(g.Index(p)); got != want {
+			t.Errorf("g.Get(%v) = %v; want %v; grid:\n%v", p, got, want, g)
+		}
+	}
+}
+
+func TestMap(t *testing.T) {
+	s := strings.TrimSpace(`
+0123
+4567
+`)
+	g := newT(t, s)
+	heights := Map(g, func(b byte) int { return int(b - '0') })
+	if got, want := heights.NRows(), g.NRows(); got != want {
+		t.Errorf("Map(New(%q)).NRows() = %v; want %v", s, got, want)
+	}
+	if got, want := heights.NCols(), g.NCols(); got != want {
+		t.Errorf("Map(New(%q)).NCols() = %v; want %v", s, got, want)
+	}
+	for p, h := range heights.All() {
+		if got, want := h, int(g.Get(p)-'0'); got != want {
+			t.Errorf("Map(New(%q)).Get(%v) = %v; want %v", s, p, got, want)
+		}
+	}
+	// Modifying the mapped grid must not affect the original.
+	heights.Set(Pos{Row: 0, Col: 0}, 9)
+	if got, want := g.String(), s; got != want {
+		t.Errorf("after modifying mapped grid: g.String() = %q; want %q", got, want)
+	}
+	if got, want := heights.String(), "9 1 2 3\n4 5 6 7"; got != want {
+		t.Errorf("heights.String() = %q; want %q", got, want)
+	}
+}
+
+func TestNew_DoesNotModifyInput(t *testing.T) {
+	// Clone the string to make sure it's stored in writable memory. Otherwise
+	// a write to it would crash rather than fail the test.
+	s := strings.Clone("abc\ndef\nghi")
+	g := newT(t, s)
+	g.Set(Pos{Row: 1, Col: 1}, '#')
+	if got, want := s, "abc\ndef\nghi"; got != want {
+		t.Errorf("after g.Set(): input = %q; want %q", got, want)
+	}
+	if got, want := g.String(), "abc\nd#f\nghi"; got != want {
+		t.Errorf("after g.Set(): g.String() = %q; want %q", got, want)
+	}
+}
+
+func TestGrid_Get_OutOfBounds(t *testing.T) {
+	g := newT(t, "abc\ndef\nghi")
+	for _, p := range []Pos{
+		{Row: -1, Col: 0},
+		{Row: 0, Col: -1},
+		{Row: 0, Col: 3}, // This would be the newline if bounds weren't checked.
+		{Row: 3, Col: 0},
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("g.Get(%v) didn't panic", p)
+				}
+			}()
+			g.Get(p)
+		}()
+	}
+}
+
+func TestGrid_Index(t *testing.T) {
+	for _, g := range []*Grid{
+		newT(t, "abc\ndef\nghi"),
+		Make(3, 3, byte('.')),
+	} {
+		seen := make([]bool, g.NIndices())
+		for p, b := range g.All() {
+			i := g.Index(p)
+			if got, want := g.Pos(i), p; got != want {
+				t.Errorf("g.Pos(g.Index(%v)) = %v; want %v", p, got, want)
+			}
+			if got, want := g.GetIndex(i), b; got != want {
+				t.Errorf("g.GetIndex(g.Index(%v)) = %q; want %q", p, got, want)
+			}
+			if seen[i] {
+				t.Errorf("g.Index(%v) = %v, which has already been returned for another position", p, i)
+			}
+			seen[i] = true
+		}
+	}
+}
+
+func TestGrid_SetIndex(t *testing.T) {
+	g := newT(t, "abc\ndef\nghi")
+	g.SetIndex(g.Index(Pos{Row: 2, Col: 0}), '#')
+	if got, want := g.Get(Pos{Row: 2, Col: 0}), byte('#'); got != want {
+		t.Errorf("after g.SetIndex(): g.Get() = %q; want %q", got, want)
+	}
+	if got, want := g.String(), "abc\ndef\n#hi"; got != want {
+		t.Errorf("after g.SetIndex(): g.String() = %q; want %q", got, want)
+	}
+}
+
+func TestGrid_StepIndex(t *testing.T) {
+	for _, g := range []*Grid{
+		newT(t, "abcd\nefgh\nijkl"),
+		Make(3, 4, byte('.')),
+	} {
+		for p := range g.All() {
+			for _, dir := range []Direction{None, Up, Down, Left, Right, TopLeft, TopRight, BottomLeft, BottomRight} {
+				want := p.Step(dir)
+				got, ok := g.StepIndex(g.Index(p), dir)
+				if wantOK := g.InBounds(want); ok != wantOK {
+					t.Errorf("g.StepIndex(g.Index(%v), %v) ok = %v; want %v", p, dir, ok, wantOK)
+					continue
+				}
+				if ok && g.Pos(got) != want {
+					t.Errorf("g.StepIndex(g.Index(%v), %v) = g.Index(%v); want g.Index(%v)", p, dir, g.Pos(got), want)
+				}
+			}
+		}
+	}
+}
+
+func TestGrid_Clone(t *testing.T) {
+	s := "abc\ndef\nghi"
+	g := newT(t, s)
+	c := g.Clone()
+	c.Set(Pos{Row: 0, Col: 0}, '#')
+	if got, want := g.String(), s; got != want {
+		t.Errorf("after modifying clone: g.String() = %q; want %q", got, want)
+	}
+	c2 := c.Clone()
+	c.Set(Pos{Row: 0, Col: 1}, '#')
+	if got, want := c2.String(), "#bc\ndef\nghi"; got != want {
+		t.Errorf("after modifying clone: c2.String() = %q; want %q", got, want)
+	}
+	if got, want := c.String(), "##c\ndef\nghi"; got != want {
+		t.Errorf("after modifying clone: c.String() = %q; want %q", got, want)
+	}
+}
+
+func TestMakeLike(t *testing.T) {
+	g := newT(t, "abc\ndef\nghi")
+	seen := MakeLike(g, false)
+	for p := range g.All() {
+		if g.Get(p) > 'd' {
+			seen.SetIndex(g.Index(p), true)
+		}
+	}
+	for p, b := range seen.All() {
+		if got, want := b, g.Get(p) > 'd'; got != want {
+			t.Errorf("seen.Get(%v) = %v; want %v", p, got, want)
+		}
+	}
+}
+
+func TestMakeLike_String(t *testing.T) {
+	g := newT(t, "abc\ndef")
+	if got, want := MakeLike(g, byte('.')).String(), "...\n..."; got != want {
+		t.Errorf("MakeLike(g, '.').String() = %q; want %q", got, want)
+	}
+	if got, want := Map(g, func(b byte) byte { return b - 'a' + 'A' }).String(), "ABC\nDEF"; got != want {
+		t.Errorf("Map(g, toUpper).String() = %q; want %q", got, want)
+	}
+}
+
+func benchmarkInput(b *testing.B) string {
+	b.Helper()
+	return aocdata.InputT(b, 2023, 16)
+}
+
+func BenchmarkNew(b *testing.B) {
+	input := benchmarkInput(b)
+	for b.Loop() {
+		if _, err := New(input); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGrid_Clone(b *testing.B) {
+	g := MustNew(benchmarkInput(b))
+	g.Set(Pos{}, g.Get(Pos{})) // Make sure the grid has storage of its own.
+	for b.Loop() {
+		g.Clone()
+	}
+}
+
+func BenchmarkGrid_Get(b *testing.B) {
+	g := MustNew(benchmarkInput(b))
+	n := 0
+	for b.Loop() {
+		for row := 0; row < g.NRows(); row++ {
+			for col := 0; col < g.NCols(); col++ {
+				if g.Get(Pos{Row: row, Col: col}) == '.' {
+					n++
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkGrid_GetIndex(b *testing.B) {
+	g := MustNew(benchmarkInput(b))
+	n := 0
+	for b.Loop() {
+		for row := 0; row < g.NRows(); row++ {
+			i := g.Index(Pos{Row: row, Col: 0})
+			for ok := true; ok; i, ok = g.StepIndex(i, Right) {
+				if g.GetIndex(i) == '.' {
+					n++
+				}
+			}
+		}
+	}
+}
+
+func TestPos_Neighbors4Seq(t *testing.T) {
+	p := Pos{Row: 3, Col: 5}
+	var gotDirs []Direction
+	got := make(map[Direction]Pos)
+	for d, n := range p.Neighbors4Seq() {
+		gotDirs = append(gotDirs, d)
+		got[d] = n
+	}
+	if diff := cmp.Diff(p.Neighbors4(), got); diff != "" {
+		t.Errorf("%v.Neighbors4Seq() differs from %v.Neighbors4() (-want +got)\n%s", p, p, diff)
+	}
+	wantDirs := []Direction{Up, Right, Down, Left}
+	if diff := cmp.Diff(wantDirs, gotDirs); diff != "" {
+		t.Errorf("%v.Neighbors4Seq() yielded directions in unexpected order (-want +got)\n%s", p, diff)
+	}
+}
+
+func TestPos_Neighbors8Seq(t *testing.T) {
+	p := Pos{Row: 3, Col: 5}
+	var gotDirs []Direction
+	got := make(map[Direction]Pos)
+	for d, n := range p.Neighbors8Seq() {
+		gotDirs = append(gotDirs, d)
+		got[d] = n
+	}
+	if diff := cmp.Diff(p.Neighbors8(), got); diff != "" {
+		t.Errorf("%v.Neighbors8Seq() differs from %v.Neighbors8() (-want +got)\n%s", p, p, diff)
+	}
+	wantDirs := []Direction{Up, TopRight, Right, BottomRight, Down, BottomLeft, Left, TopLeft}
+	if diff := cmp.Diff(wantDirs, gotDirs); diff != "" {
+		t.Errorf("%v.Neighbors8Seq() yielded directions in unexpected order (-want +got)\n%s", p, diff)
+	}
+}
+
+func TestGrid_Neighbors(t *testing.T) {
+	g := newT(t, "abc\ndef\nghi")
+	for _, tt := range []struct {
+		p     Pos
+		want4 []Direction
+		want8 []Direction
+	}{
+		{
+			p:     Pos{Row: 0, Col: 0},
+			want4: []Direction{Right, Down},
+			want8: []Direction{Right, BottomRight, Down},
+		},
+		{
+			p:     Pos{Row: 1, Col: 1},
+			want4: []Direction{Up, Right, Down, Left},
+			want8: []Direction{Up, TopRight, Right, BottomRight, Down, BottomLeft, Left, TopLeft},
+		},
+		{
+			p:     Pos{Row: 2, Col: 1},
+			want4: []Direction{Up, Right, Left},
+			want8: []Direction{Up, TopRight, Right, Left, TopLeft},
+		},
+	} {
+		var got4, got8 []Direction
+		for d, n := range g.Neighbors4(tt.p) {
+			if n != tt.p.Step(d) {
+				t.Errorf("g.Neighbors4(%v) yielded (%v, %v); want (%v, %v)", tt.p, d, n, d, tt.p.Step(d))
+			}
+			got4 = append(got4, d)
+		}
+		for d, n := range g.Neighbors8(tt.p) {
+			if n != tt.p.Step(d) {
+				t.Errorf("g.Neighbors8(%v) yielded (%v, %v); want (%v, %v)", tt.p, d, n, d, tt.p.Step(d))
+			}
+			got8 = append(got8, d)
+		}
+		if diff := cmp.Diff(tt.want4, got4); diff != "" {
+			t.Errorf("g.Neighbors4(%v) yielded unexpected directions (-want +got)\n%s", tt.p, diff)
+		}
+		if diff := cmp.Diff(tt.want8, got8); diff != "" {
+			t.Errorf("g.Neighbors8(%v) yielded unexpected directions (-want +got)\n%s", tt.p, diff)
+		}
+	}
+}
+
+func BenchmarkPos_Neighbors4(b *testing.B) {
+	p := Pos{Row: 3, Col: 5}
+	sum := 0
+	for b.Loop() {
+		for _, n := range p.Neighbors4() {
+			sum += n.Row + n.Col
+		}
+	}
+}
+
+func BenchmarkPos_Neighbors4Seq(b *testing.B) {
+	p := Pos{Row: 3, Col: 5}
+	sum := 0
+	for b.Loop() {
+		for _, n := range p.Neighbors4Seq() {
+			sum += n.Row + n.Col
+		}
+	}
+}
+
+func BenchmarkPos_Neighbors8(b *testing.B) {
+	p := Pos{Row: 3, Col: 5}
+	sum := 0
+	for b.Loop() {
+		for _, n := range p.Neighbors8() {
+			sum += n.Row + n.Col
+		}
+	}
+}
+
+func BenchmarkPos_Neighbors8Seq(b *testing.B) {
+	p := Pos{Row: 3, Col: 5}
+	sum := 0
+	for b.Loop() {
+		for _, n := range p.Neighbors8Seq() {
+			sum += n.Row + n.Col
+		}
+	}
+}
+
+func BenchmarkGrid_Neighbors4(b *testing.B) {
+	g := MustNew(benchmarkInput(b))
+	sum := 0
+	for b.Loop() {
+		for p := range g.All() {
+			for _, n := range g.Neighbors4(p) {
+				sum += n.Row + n.Col
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package asciigrid
+
+import (
+	"iter"
+	"slices"
+)
+
+// Edge is a path through a corridor in a grid, between two nodes of a Graph.
+type Edge struct {
+	From, To Pos
+	// Length is the number of steps from From to To.
+	Length int
+}
+
+// Graph is a weighted, directed graph made by compressing the corridors of a
+// grid. Its nodes are positions in the grid, and each edge is a corridor
+// between two nodes that doesn't pass any other node.
+//
+// The Neighbors method makes a Graph usable with the searches in the search
+// package, where the states are the nodes.
+type Graph struct {
+	nodes []Pos
+	edges map[Pos][]Edge
+}
+
+// Compress turns the corridors of g into a Graph. canStep reports whether one
+// can take a step from the position from in direction d; it's only called for
+// steps that stay within the grid. A position is open if it's possible to step
+// into it.
+//
+// The nodes of the graph are the open positions where the path branches
+// (junctions, with three or more open neighbors), the open positions where it
+// ends (dead ends, with at most one open neighbor), and any open position for
+// which keep returns true (points of interest, like the start and the goal). A
+// nil keep keeps no extra positions.
+//
+// Every other open position is in a corridor: it has exactly two open
+// neighbors, and walking along it leads from one node to another. The graph has
+// an edge for each such walk that is possible according to canStep. If there
+// are several corridors between the same two nodes, the graph has an edge for
+// each of them.
+func (g *GridOf[T]) Compress(canStep func(from Pos, d Direction) bool, keep func(p Pos) bool) *Graph {
+	// openNeighbors returns the number of neighbors of p that one can step
+	// between p and, and whether p is open.
+	openNeighbors := func(p Pos) (n int, open bool) {
+		for d, q := range g.Neighbors4(p) {
+			in := canStep(q, d.Inverse())
+			if in || canStep(p, d) {
+				n++
+			}
+			open = open || in
+		}
+		return n, open
+	}
+	gr := &Graph{edges: make(map[Pos][]Edge)}
+	isNode := MakeLike(g, false)
+	for p := range g.Bounds().All() {
+		n, open := openNeighbors(p)
+		if !open {
+			continue
+		}
+		if n != 2 || (keep != nil && keep(p)) {
+			gr.nodes = append(gr.nodes, p)
+			isNode.Set(p, true)
+		}
+	}
+	for _, from := range gr.nodes {
+		for d, p := range g.Neighbors4(from) {
+			if !canStep(from, d) {
+				continue
+			}
+			e := Edge{From: from, Length: 1}
+			cameFrom := d.Inverse()
+			for ok := true; ok && !isNode.Get(p); {
+				// This is a corridor, so there is only one way to go that
+				// isn't back where we came from.
+				ok = false
+				for d, q := range g.Neighbors4(p) {
+					if d != cameFrom && canStep(p, d) {
+						p = q
+						cameFrom = d.Inverse()
+						e.Length++
+						ok = true
+						break
+					}
+				}
+			}
+			if isNode.Get(p) {
+				e.To = p
+				gr.edges[from] = append(gr.edges[from], e)
+			}
+		}
+	}
+	return gr
+}
+
+// Slopes returns a function to use with Compress for a maze where wall is
+// impassable, and the slope characters '^', '>', 'v' and '<' can't be entered
+// going against the direction they point.
+func Slopes(g *Grid, wall byte) func(from Pos, d Direction) bool {
+	uphill := [...]byte{
+		Up:    'v',
+		Right: '<',
+		Down:  '^',
+		Left:  '>',
+	}
+	return func(from Pos, d Direction) bool {
+		if g.Get(from) == wall {
+			return false
+		}
+		to := g.Get(from.Step(d))
+		if to == wall {
+			return false
+		}
+		if int(d) < len(uphill) && uphill[d] != 0 && to == uphill[d] {
+			return false
+		}
+		return true
+	}
+}
+
+// Nodes returns the nodes of the graph, in row-major order.
+func (gr *Graph) Nodes() []Pos {
+	return slices.Clone(gr.nodes)
+}
+
+// Edges returns the edges leading out of p, ordered by the direction of their
+// first step in the same order as Neighbors4.
+func (gr *Graph) Edges(p Pos) []Edge {
+	return gr.edges[p]
+}
+
+// Neighbors iterates over the nodes that can be reached from p by following a
+// single edge, and the length of that edge. It has the signature expected by
+// search.Dijkstra and the other weighted searches.
+func (gr *Graph) Neighbors(p Pos) iter.Seq2[Pos, int] {
+	return func(yield func(Pos, int) bool) {
+		for _, e := range gr.edges[p] {
+			if !yield(e.To, e.Length) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package asciigrid
+
+import (
+	"iter"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.saser.se/adventofgo/search"
+)
+
+func TestGrid_Compress(t *testing.T) {
+	g := newT(t, strings.TrimSpace(`
+S...#
+.##.#
+....E
+`))
+	start := Pos{Row: 0, Col: 0}
+	junction := Pos{Row: 2, Col: 3}
+	end := Pos{Row: 2, Col: 4}
+	gr := g.Compress(Slopes(g, '#'), func(p Pos) bool { return p == start })
+	if diff := cmp.Diff([]Pos{start, junction, end}, gr.Nodes()); diff != "" {
+		t.Errorf("Nodes() returned unexpected nodes (-want +got)\n%s", diff)
+	}
+	want := map[Pos][]Edge{
+		start: {
+			{From: start, To: junction, Length: 5}, // Along the top.
+			{From: start, To: junction, Length: 5}, // Along the bottom.
+		},
+		junction: {
+			{From: junction, To: start, Length: 5}, // Up.
+			{From: junction, To: end, Length: 1},   // Right.
+			{From: junction, To: start, Length: 5}, // Left.
+		},
+		end: {
+			{From: end, To: junction, Length: 1},
+		},
+	}
+	for p, want := range want {
+		if diff := cmp.Diff(want, gr.Edges(p)); diff != "" {
+			t.Errorf("Edges(%v) returned unexpected edges (-want +got)\n%s", p, diff)
+		}
+	}
+}
+
+func TestGrid_Compress_Slopes(t *testing.T) {
+	g := newT(t, "..>..")
+	gr := g.Compress(Slopes(g, '#'), nil)
+	left, right := Pos{Row: 0, Col: 0}, Pos{Row: 0, Col: 4}
+	if diff := cmp.Diff([]Edge{{From: left, To: right, Length: 4}}, gr.Edges(left)); diff != "" {
+		t.Errorf("Edges(%v) returned unexpected edges (-want +got)\n%s", left, diff)
+	}
+	if got := gr.Edges(right); len(got) != 0 {
+		t.Errorf("Edges(%v) = %v; want none, as the slope can't be climbed", right, got)
+	}
+}
+
+// TestGrid_Compress_Distances checks that the shortest distances between nodes
+// are the same in random mazes and their compressed graphs.
+func TestGrid_Compress_Distances(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	for range 20 {
+		g := Make(1+rng.IntN(15), 1+rng.IntN(15), byte('.'))
+		for p := range g.Bounds().All() {
+			if rng.IntN(3) == 0 {
+				g.Set(p, '#')
+			}
+		}
+		gr := g.Compress(Slopes(g, '#'), nil)
+		open := func(p Pos) iter.Seq[Pos] {
+			return func(yield func(Pos) bool) {
+				for _, q := range g.Neighbors4(p) {
+					if g.Get(q) != '#' && !yield(q) {
+						return
+					}
+				}
+			}
+		}
+		for _, from := range gr.Nodes() {
+			want := search.BFS(from, open, nil)
+			got := search.Dijkstra(from, gr.Neighbors, nil)
+			for _, to := range gr.Nodes() {
+				wantDist, wantOK := want.Dist(to)
+				gotDist, gotOK := got.Dist(to)
+				if gotDist != wantDist || gotOK != wantOK {
+					t.Errorf("distance from %v to %v in compressed graph = %v, %v; want %v, %v in grid\n%v", from, to, gotDist, gotOK, wantDist, wantOK, g)
+				}
+			}
+		}
+	}
+}
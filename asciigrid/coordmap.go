@@ -0,0 +1,146 @@
+package asciigrid
+
+import (
+	"slices"
+
+	"go.saser.se/adventofgo/math/span"
+)
+
+// Axis compresses coordinates along one axis, so that a handful of
+// interesting values spread out over a huge range can be worked with in a
+// small grid. Every interesting value gets a cell of its own, and the values
+// between two consecutive interesting values share a single cell. For
+// example, the interesting values 0, 5 and 6 give the cells
+//
+//	[0, 1) [1, 5) [5, 6) [6, 7)
+//
+// with widths 1, 4, 1 and 1.
+type Axis struct {
+	// starts holds the first coordinate of each cell, in increasing order.
+	// The last cell is always a single interesting value.
+	starts []int
+}
+
+// NewAxis returns an Axis where the given values are interesting. The values
+// may be in any order and contain duplicates.
+func NewAxis(values []int) *Axis {
+	vs := slices.Clone(values)
+	slices.Sort(vs)
+	vs = slices.Compact(vs)
+	a := &Axis{starts: make([]int, 0, 2*len(vs))}
+	for i, v := range vs {
+		if i > 0 && vs[i-1]+1 < v {
+			a.starts = append(a.starts, vs[i-1]+1)
+		}
+		a.starts = append(a.starts, v)
+	}
+	return a
+}
+
+// Len returns the number of cells.
+func (a *Axis) Len() int {
+	return len(a.starts)
+}
+
+// Index returns the cell that v is in, and true. If v is before the first or
+// after the last interesting value, Index returns false.
+func (a *Axis) Index(v int) (int, bool) {
+	i, found := slices.BinarySearch(a.starts, v)
+	if found {
+		return i, true
+	}
+	if i == 0 || i == len(a.starts) {
+		return 0, false
+	}
+	return i - 1, true
+}
+
+// Span returns the coordinates in cell i.
+func (a *Axis) Span(i int) span.Span[int] {
+	if i == len(a.starts)-1 {
+		return span.New(a.starts[i], a.starts[i]+1)
+	}
+	return span.New(a.starts[i], a.starts[i+1])
+}
+
+// Width returns the number of coordinates in cell i.
+func (a *Axis) Width(i int) int {
+	return a.Span(i).Len()
+}
+
+// CoordMap compresses positions in a huge grid, like the trench dug by
+// following the hex-encoded instructions in 2023 day 18, into positions in a
+// small grid. Rows and columns are compressed separately by an Axis each, so
+// every position in the small grid stands for a rectangle of positions in the
+// huge grid, with an area that's the weight of the position.
+//
+// A common way to use a CoordMap is to make a grid of its size with
+// MakeCompressed, draw lines between the interesting positions in it, flood
+// fill it, and add up the weights of the filled positions with Area. Lines
+// stay straight, since two positions that share a row in the huge grid share
+// a row in the small grid too. To be able to flood fill around the outside of
+// everything, include a value just before and just after the interesting ones
+// on each axis.
+type CoordMap struct {
+	Rows, Cols *Axis
+}
+
+// NewCoordMap returns a CoordMap where the given rows and columns are
+// interesting.
+func NewCoordMap(rows, cols []int) *CoordMap {
+	return &CoordMap{Rows: NewAxis(rows), Cols: NewAxis(cols)}
+}
+
+// CoordMapOf returns a CoordMap where the rows and columns of the given
+// positions are interesting, as well as the rows and columns just outside
+// them, so that there is room to go around them.
+func CoordMapOf(ps []Pos) *CoordMap {
+	if len(ps) == 0 {
+		return NewCoordMap(nil, nil)
+	}
+	bounds := RectOf(ps...)
+	rows := []int{bounds.Min.Row - 1, bounds.Max.Row}
+	cols := []int{bounds.Min.Col - 1, bounds.Max.Col}
+	for _, p := range ps {
+		rows = append(rows, p.Row)
+		cols = append(cols, p.Col)
+	}
+	return NewCoordMap(rows, cols)
+}
+
+// MakeCompressed is like Make, with one position per cell of m.
+func MakeCompressed[T any](m *CoordMap, fill T) *GridOf[T] {
+	return Make(m.Rows.Len(), m.Cols.Len(), fill)
+}
+
+// Pos returns the position in the small grid that p is in, and true. If p is
+// outside of the interesting rows and columns, Pos returns false.
+func (m *CoordMap) Pos(p Pos) (Pos, bool) {
+	row, ok1 := m.Rows.Index(p.Row)
+	col, ok2 := m.Cols.Index(p.Col)
+	return Pos{Row: row, Col: col}, ok1 && ok2
+}
+
+// Rect returns the positions in the huge grid that c stands for.
+func (m *CoordMap) Rect(c Pos) Rect {
+	rows, cols := m.Rows.Span(c.Row), m.Cols.Span(c.Col)
+	return Rect{
+		Min: Pos{Row: rows.Start, Col: cols.Start},
+		Max: Pos{Row: rows.End, Col: cols.End},
+	}
+}
+
+// Weight returns the number of positions in the huge grid that c stands for.
+func (m *CoordMap) Weight(c Pos) int {
+	return m.Rows.Width(c.Row) * m.Cols.Width(c.Col)
+}
+
+// Area returns the number of positions in the huge grid that the given
+// positions in the small grid stand for.
+func (m *CoordMap) Area(cells []Pos) int {
+	area := 0
+	for _, c := range cells {
+		area += m.Weight(c)
+	}
+	return area
+}
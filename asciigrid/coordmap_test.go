@@ -0,0 +1,132 @@
+package asciigrid
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.saser.se/adventofgo/math/span"
+)
+
+func TestAxis(t *testing.T) {
+	a := NewAxis([]int{6, 0, 5, 5})
+	var got []span.Span[int]
+	for i := range a.Len() {
+		got = append(got, a.Span(i))
+	}
+	want := []span.Span[int]{span.New(0, 1), span.New(1, 5), span.New(5, 6), span.New(6, 7)}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("cells returned unexpected result (-want +got)\n%s", diff)
+	}
+}
+
+func TestAxis_Random(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 200 {
+		values := make([]int, r.IntN(6)+1)
+		for i := range values {
+			values[i] = r.IntN(30) - 10
+		}
+		a := NewAxis(values)
+		lo, hi := slices.Min(values), slices.Max(values)
+		// The cells must cover [lo, hi] without gaps or overlaps, and every
+		// interesting value must have a cell of its own.
+		next := lo
+		for i := range a.Len() {
+			s := a.Span(i)
+			if s.Start != next || s.Len() <= 0 {
+				t.Fatalf("NewAxis(%v): cell %d is %v; want a non-empty span starting at %d", values, i, s, next)
+			}
+			if slices.Contains(values, s.Start) && s.Len() != 1 {
+				t.Errorf("NewAxis(%v): interesting value %d is in cell %v", values, s.Start, s)
+			}
+			next = s.End
+		}
+		if next != hi+1 {
+			t.Errorf("NewAxis(%v): cells end at %d; want %d", values, next, hi+1)
+		}
+		for v := lo - 2; v <= hi+2; v++ {
+			i, ok := a.Index(v)
+			if want := lo <= v && v <= hi; ok != want {
+				t.Fatalf("NewAxis(%v).Index(%d) ok = %v; want %v", values, v, ok, want)
+			}
+			if ok && a.Span(i).Contains(v) != 0 {
+				t.Errorf("NewAxis(%v).Index(%d) = %d, which is %v", values, v, i, a.Span(i))
+			}
+		}
+	}
+}
+
+func TestCoordMap_Lagoon(t *testing.T) {
+	// Part 2 of the example from 2023 day 18, where the hex codes give
+	// distances far too large for a dense grid.
+	codes := []string{
+		"70c710", "0dc571", "5713f0", "d2c081", "59c680", "411b91", "8ceee2",
+		"caa173", "1b58a2", "caa171", "7807d2", "a77fa3", "015232", "7a21e3",
+	}
+	dirs := map[byte]Direction{'0': Right, '1': Down, '2': Left, '3': Up}
+	corners := []Pos{{Row: 0, Col: 0}}
+	for _, code := range codes {
+		n, err := strconv.ParseInt(code[:5], 16, 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		corners = append(corners, corners[len(corners)-1].StepN(dirs[code[5]], int(n)))
+	}
+
+	m := CoordMapOf(corners)
+	g := MakeCompressed(m, byte('.'))
+	for i := 1; i < len(corners); i++ {
+		from, ok1 := m.Pos(corners[i-1])
+		to, ok2 := m.Pos(corners[i])
+		if !ok1 || !ok2 {
+			t.Fatalf("corners %v and %v are not in the compressed grid", corners[i-1], corners[i])
+		}
+		for p := range RectOf(from, to).All() {
+			g.Set(p, '#')
+		}
+	}
+	// Everything that can't be reached from the margin around the loop is
+	// part of the lagoon. The outside is counted in positions of the huge
+	// grid by weighing the positions of the small grid.
+	outside := g.FloodFill(Pos{Row: 0, Col: 0}, func(_, to Pos) bool { return g.Get(to) == '.' })
+	total := m.Area(slices.Collect(g.Bounds().All()))
+	if got, want := total-m.Area(outside.Cells), 952408144115; got != want {
+		t.Errorf("lagoon size = %d; want %d\n%s", got, want, strings.TrimSpace(g.String()))
+	}
+}
+
+func TestCoordMap_Weight(t *testing.T) {
+	m := NewCoordMap([]int{0, 10}, []int{-3, 3, 4})
+	// Rows: [0, 1) [1, 10) [10, 11). Columns: [-3, -2) [-2, 3) [3, 4) [4, 5).
+	for _, tt := range []struct {
+		p      Pos
+		want   Pos
+		weight int
+	}{
+		{p: Pos{Row: 0, Col: -3}, want: Pos{Row: 0, Col: 0}, weight: 1},
+		{p: Pos{Row: 5, Col: 0}, want: Pos{Row: 1, Col: 1}, weight: 9 * 5},
+		{p: Pos{Row: 10, Col: 4}, want: Pos{Row: 2, Col: 3}, weight: 1},
+	} {
+		got, ok := m.Pos(tt.p)
+		if !ok || got != tt.want {
+			t.Errorf("Pos(%v) = %v, %v; want %v, true", tt.p, got, ok, tt.want)
+			continue
+		}
+		if !m.Rect(got).Contains(tt.p) {
+			t.Errorf("Rect(%v) = %v doesn't contain %v", got, m.Rect(got), tt.p)
+		}
+		if w := m.Weight(got); w != tt.weight {
+			t.Errorf("Weight(%v) = %d; want %d", got, w, tt.weight)
+		}
+	}
+	if p, ok := m.Pos(Pos{Row: 11, Col: 0}); ok {
+		t.Errorf("Pos(11, 0) = %v, true; want false", p)
+	}
+	if got, want := m.Area(slices.Collect(MakeCompressed(m, 0).Bounds().All())), 11*8; got != want {
+		t.Errorf("total area = %d; want %d", got, want)
+	}
+}
@@ -0,0 +1,64 @@
+package asciigrid
+
+import (
+	"bytes"
+	"fmt"
+	"iter"
+	"strconv"
+)
+
+// Find returns the first position in row-major order holding v, and whether
+// there was one. It is typically used to find the start and end markers of a
+// maze:
+//
+//	start, _ := asciigrid.Find(g, 'S')
+func Find[T comparable](g *GridOf[T], v T) (Pos, bool) {
+	for p := range FindAll(g, v) {
+		return p, true
+	}
+	return Pos{}, false
+}
+
+// MustFind is like Find but panics if v isn't in the grid.
+func MustFind[T comparable](g *GridOf[T], v T) Pos {
+	p, ok := Find(g, v)
+	if !ok {
+		var what string
+		if b, ok := any(v).(byte); ok {
+			what = strconv.QuoteRune(rune(b))
+		} else {
+			what = fmt.Sprint(v)
+		}
+		panic(fmt.Errorf("asciigrid: %s not found in grid", what))
+	}
+	return p
+}
+
+// FindAll iterates over the positions holding v, in row-major order.
+func FindAll[T comparable](g *GridOf[T], v T) iter.Seq[Pos] {
+	return func(yield func(Pos) bool) {
+		if cells, ok := any(g.cells).([]byte); ok {
+			b := any(v).(byte)
+			for row := range g.nRows {
+				start := row * g.stride
+				line := cells[start : start+g.nCols]
+				for col := 0; ; col++ {
+					i := bytes.IndexByte(line[col:], b)
+					if i == -1 {
+						break
+					}
+					col += i
+					if !yield(Pos{Row: row, Col: col}) {
+						return
+					}
+				}
+			}
+			return
+		}
+		for p, w := range g.All() {
+			if w == v && !yield(p) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package asciigrid
+
+import (
+	"fmt"
+	"strings"
+
+	"go.saser.se/adventofgo/geometry"
+)
+
+// Positions in a grid and points in the geometry package use different
+// conventions. A Pos has a row, which grows downward, and a column, which grows
+// to the right. A geometry.Pos2 has X and Y, where X grows to the right and Y
+// either grows downward, like in most puzzles that give coordinates as "x,y",
+// or upward, like in puzzles where '^' or "N" increases Y. The conversions below
+// come in pairs, one for each convention:
+//
+//	                 Y down          Y up
+//	Pos -> Pos2      p.Pos2()        p.Pos2YUp()
+//	Pos2 -> Pos      FromPos2(p)     FromPos2YUp(p)
+//	Direction        d.Vector()      d.VectorYUp()
+
+// Pos2 returns p as a point where X is the column and Y is the row, so that Y
+// grows downward.
+func (p Pos) Pos2() geometry.Pos2 {
+	return geometry.Pos2{X: p.Col, Y: p.Row}
+}
+
+// Pos2YUp returns p as a point where X is the column and Y is the negated row,
+// so that Y grows upward.
+func (p Pos) Pos2YUp() geometry.Pos2 {
+	return geometry.Pos2{X: p.Col, Y: -p.Row}
+}
+
+// FromPos2 returns the position of a point where Y grows downward. It's the
+// inverse of Pos.Pos2.
+func FromPos2(p geometry.Pos2) Pos {
+	return Pos{Row: p.Y, Col: p.X}
+}
+
+// FromPos2YUp returns the position of a point where Y grows upward. It's the
+// inverse of Pos.Pos2YUp.
+func FromPos2YUp(p geometry.Pos2) Pos {
+	return Pos{Row: -p.Y, Col: p.X}
+}
+
+// Vector returns the change in X and Y from taking a single step in direction
+// d, where Y grows downward. For example, Up is {X: 0, Y: -1}.
+func (d Direction) Vector() geometry.Pos2 {
+	dRow, dCol := d.delta()
+	return geometry.Pos2{X: dCol, Y: dRow}
+}
+
+// VectorYUp returns the change in X and Y from taking a single step in
+// direction d, where Y grows upward. For example, Up is {X: 0, Y: 1}.
+func (d Direction) VectorYUp() geometry.Pos2 {
+	dRow, dCol := d.delta()
+	return geometry.Pos2{X: dCol, Y: -dRow}
+}
+
+// Notation is a set of names for directions, the way a puzzle writes them. A
+// notation only accepts its own names, in the case it spells them in, so
+// anything else in the input is reported as an error instead of being read as
+// some other direction.
+type Notation struct {
+	names  [BottomRight + 1]string
+	byName map[string]Direction
+	// byByte holds the directions named by a single character, and None for
+	// other characters, since most puzzles list their directions one
+	// character at a time.
+	byByte [256]Direction
+}
+
+// NewNotation returns a notation where names[d] is the name of direction d.
+// Directions without a name are not part of the notation. It panics if two
+// directions have the same name.
+func NewNotation(names map[Direction]string) *Notation {
+	n := &Notation{byName: make(map[string]Direction, len(names))}
+	for d, name := range names {
+		if _, ok := n.byName[name]; ok {
+			panic(fmt.Sprintf("asciigrid: NewNotation: %q names more than one direction", name))
+		}
+		n.names[d] = name
+		n.byName[name] = d
+		if len(name) == 1 {
+			n.byByte[name[0]] = d
+		}
+	}
+	return n
+}
+
+var (
+	// Arrows names the directions "^", ">", "v" and "<".
+	Arrows = NewNotation(map[Direction]string{Up: "^", Right: ">", Down: "v", Left: "<"})
+	// Letters names the directions "U", "R", "D" and "L".
+	Letters = NewNotation(map[Direction]string{Up: "U", Right: "R", Down: "D", Left: "L"})
+	// Compass names the directions "N", "NE", "E", "SE", "S", "SW", "W" and
+	// "NW", where north is Up.
+	Compass = NewNotation(map[Direction]string{
+		Up:          "N",
+		TopRight:    "NE",
+		Right:       "E",
+		BottomRight: "SE",
+		Down:        "S",
+		BottomLeft:  "SW",
+		Left:        "W",
+		TopLeft:     "NW",
+	})
+)
+
+// ParseDirection returns the direction named s in n.
+func (n *Notation) ParseDirection(s string) (Direction, error) {
+	if len(s) == 1 {
+		if d := n.byByte[s[0]]; d != None {
+			return d, nil
+		}
+	} else if d, ok := n.byName[s]; ok {
+		return d, nil
+	}
+	return None, fmt.Errorf("asciigrid: invalid direction %q", s)
+}
+
+// Name returns the name of d in n, or "" if n has no name for d.
+func (n *Notation) Name(d Direction) string {
+	if d < 0 || int(d) >= len(n.names) {
+		return ""
+	}
+	return n.names[d]
+}
+
+// anyNotation accepts the names of all the notations above, in either case.
+var anyNotation = func() *Notation {
+	all := &Notation{byName: make(map[string]Direction)}
+	for _, n := range []*Notation{Arrows, Letters, Compass} {
+		for name, d := range n.byName {
+			all.byName[strings.ToLower(name)] = d
+			if len(name) == 1 {
+				all.byByte[name[0]] = d
+				all.byByte[strings.ToLower(name)[0]] = d
+			}
+		}
+	}
+	return all
+}()
+
+// ParseDirection returns the direction named by s in any of the notations
+// Arrows, Letters and Compass, where letters may be in upper or lower case. It
+// suits input that mixes notations; for input that uses a single notation,
+// that notation's ParseDirection also catches names that don't belong.
+func ParseDirection(s string) (Direction, error) {
+	if len(s) > 1 {
+		// Single characters are looked up in either case already.
+		s = strings.ToLower(s)
+	}
+	return anyNotation.ParseDirection(s)
+}
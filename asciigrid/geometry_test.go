@@ -0,0 +1,146 @@
+package asciigrid
+
+import (
+	"testing"
+
+	"go.saser.se/adventofgo/geometry"
+)
+
+func TestPos2_RoundTrip(t *testing.T) {
+	for _, p := range []Pos{{Row: 0, Col: 0}, {Row: 3, Col: -7}, {Row: -2, Col: 5}} {
+		if got := FromPos2(p.Pos2()); got != p {
+			t.Errorf("FromPos2(%v.Pos2()) = %v; want %v", p, got, p)
+		}
+		if got := FromPos2YUp(p.Pos2YUp()); got != p {
+			t.Errorf("FromPos2YUp(%v.Pos2YUp()) = %v; want %v", p, got, p)
+		}
+	}
+	p := Pos{Row: 2, Col: 5}
+	if got, want := p.Pos2(), (geometry.Pos2{X: 5, Y: 2}); got != want {
+		t.Errorf("%v.Pos2() = %v; want %v", p, got, want)
+	}
+	if got, want := p.Pos2YUp(), (geometry.Pos2{X: 5, Y: -2}); got != want {
+		t.Errorf("%v.Pos2YUp() = %v; want %v", p, got, want)
+	}
+}
+
+func TestDirection_Vector(t *testing.T) {
+	p := Pos{Row: 4, Col: 9}
+	for _, d := range []Direction{None, Up, Down, Left, Right, TopLeft, TopRight, BottomLeft, BottomRight} {
+		if got, want := p.Pos2().Add(d.Vector()), p.Step(d).Pos2(); got != want {
+			t.Errorf("stepping %v with Vector: got %v; want %v", d, got, want)
+		}
+		if got, want := p.Pos2YUp().Add(d.VectorYUp()), p.Step(d).Pos2YUp(); got != want {
+			t.Errorf("stepping %v with VectorYUp: got %v; want %v", d, got, want)
+		}
+	}
+	if got, want := Up.Vector(), (geometry.Pos2{X: 0, Y: -1}); got != want {
+		t.Errorf("Up.Vector() = %v; want %v", got, want)
+	}
+	if got, want := Up.VectorYUp(), (geometry.Pos2{X: 0, Y: 1}); got != want {
+		t.Errorf("Up.VectorYUp() = %v; want %v", got, want)
+	}
+}
+
+func TestParseDirection(t *testing.T) {
+	for _, tt := range []struct {
+		names []string
+		want  Direction
+	}{
+		{names: []string{"^", "U", "u", "N", "n"}, want: Up},
+		{names: []string{"v", "D", "d", "S", "s"}, want: Down},
+		{names: []string{"<", "L", "l", "W", "w"}, want: Left},
+		{names: []string{">", "R", "r", "E", "e"}, want: Right},
+		{names: []string{"NE", "ne"}, want: TopRight},
+		{names: []string{"NW", "nw"}, want: TopLeft},
+		{names: []string{"SE", "se"}, want: BottomRight},
+		{names: []string{"SW", "sw"}, want: BottomLeft},
+	} {
+		for _, name := range tt.names {
+			got, err := ParseDirection(name)
+			if err != nil {
+				t.Errorf("ParseDirection(%q) err = %v; want nil", name, err)
+				continue
+			}
+			if got != tt.want {
+				t.Errorf("ParseDirection(%q) = %v; want %v", name, got, tt.want)
+			}
+		}
+	}
+	for _, name := range []string{"", "x", ".", "up", "nn", "\n"} {
+		if got, err := ParseDirection(name); err == nil {
+			t.Errorf("ParseDirection(%q) = %v, nil; want error", name, got)
+		}
+	}
+}
+
+func TestNotation(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		n     *Notation
+		names map[Direction]string
+		// invalid holds names that other notations use, or that differ only
+		// in case.
+		invalid []string
+	}{
+		{
+			name:    "Arrows",
+			n:       Arrows,
+			names:   map[Direction]string{Up: "^", Right: ">", Down: "v", Left: "<"},
+			invalid: []string{"V", "U", "N", ""},
+		},
+		{
+			name:    "Letters",
+			n:       Letters,
+			names:   map[Direction]string{Up: "U", Right: "R", Down: "D", Left: "L"},
+			invalid: []string{"u", "^", "N", "E", "UR"},
+		},
+		{
+			name: "Compass",
+			n:    Compass,
+			names: map[Direction]string{
+				Up:          "N",
+				TopRight:    "NE",
+				Right:       "E",
+				BottomRight: "SE",
+				Down:        "S",
+				BottomLeft:  "SW",
+				Left:        "W",
+				TopLeft:     "NW",
+			},
+			invalid: []string{"n", "ne", "U", ">", "NN"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for d, name := range tt.names {
+				if got, err := tt.n.ParseDirection(name); err != nil || got != d {
+					t.Errorf("ParseDirection(%q) = %v, %v; want %v, nil", name, got, err, d)
+				}
+				if got := tt.n.Name(d); got != name {
+					t.Errorf("Name(%v) = %q; want %q", d, got, name)
+				}
+			}
+			for _, name := range tt.invalid {
+				if got, err := tt.n.ParseDirection(name); err == nil {
+					t.Errorf("ParseDirection(%q) = %v, nil; want error", name, got)
+				}
+			}
+			if got := tt.n.Name(None); got != "" {
+				t.Errorf("Name(None) = %q; want \"\"", got)
+			}
+		})
+	}
+}
+
+func TestNewNotation_Digits(t *testing.T) {
+	// Like the hexadecimal digits in 2023 day 18.
+	n := NewNotation(map[Direction]string{Right: "0", Down: "1", Left: "2", Up: "3"})
+	for name, want := range map[string]Direction{"0": Right, "1": Down, "2": Left, "3": Up} {
+		if got, err := n.ParseDirection(name); err != nil || got != want {
+			t.Errorf("ParseDirection(%q) = %v, %v; want %v, nil", name, got, err, want)
+		}
+	}
+	if got, err := n.ParseDirection("4"); err == nil {
+		t.Errorf("ParseDirection(%q) = %v, nil; want error", "4", got)
+	}
+}
@@ -0,0 +1,98 @@
+package asciigrid
+
+import "iter"
+
+// Ray iterates over the positions and values in a straight line starting at p
+// and going in direction d, until it leaves the grid. The line includes p, so
+// start at p.Step(d) to only look at what's beyond it. If p is out of bounds,
+// Ray yields nothing, and if d is None it yields only p.
+func (g *GridOf[T]) Ray(p Pos, d Direction) iter.Seq2[Pos, T] {
+	return func(yield func(Pos, T) bool) {
+		dRow, dCol := d.delta()
+		step := dRow*g.stride + dCol
+		i := p.Row*g.stride + p.Col
+		for g.InBounds(p) {
+			if !yield(p, g.cells[i]) || step == 0 {
+				return
+			}
+			p.Row += dRow
+			p.Col += dCol
+			i += step
+		}
+	}
+}
+
+// Rows iterates over the rows of the grid from top to bottom. Each row iterates
+// over its positions and values from left to right.
+func (g *GridOf[T]) Rows() iter.Seq[iter.Seq2[Pos, T]] {
+	return func(yield func(iter.Seq2[Pos, T]) bool) {
+		for row := range g.nRows {
+			if !yield(g.Ray(Pos{Row: row, Col: 0}, Right)) {
+				return
+			}
+		}
+	}
+}
+
+// Cols iterates over the columns of the grid from left to right. Each column
+// iterates over its positions and values from top to bottom.
+func (g *GridOf[T]) Cols() iter.Seq[iter.Seq2[Pos, T]] {
+	return func(yield func(iter.Seq2[Pos, T]) bool) {
+		for col := range g.nCols {
+			if !yield(g.Ray(Pos{Row: 0, Col: col}, Down)) {
+				return
+			}
+		}
+	}
+}
+
+// Diagonals iterates over all diagonal lines in the grid, in both directions.
+// First come the lines going down and to the right, starting with the one in
+// the bottom-left corner and ending with the one in the top-right corner. Then
+// come the lines going down and to the left, starting with the one in the
+// top-left corner and ending with the one in the bottom-right corner. Each line
+// iterates over its positions and values from top to bottom.
+func (g *GridOf[T]) Diagonals() iter.Seq[iter.Seq2[Pos, T]] {
+	return func(yield func(iter.Seq2[Pos, T]) bool) {
+		if g.nRows == 0 || g.nCols == 0 {
+			return
+		}
+		for row := g.nRows - 1; row > 0; row-- {
+			if !yield(g.Ray(Pos{Row: row, Col: 0}, BottomRight)) {
+				return
+			}
+		}
+		for col := range g.nCols {
+			if !yield(g.Ray(Pos{Row: 0, Col: col}, BottomRight)) {
+				return
+			}
+		}
+		for col := range g.nCols {
+			if !yield(g.Ray(Pos{Row: 0, Col: col}, BottomLeft)) {
+				return
+			}
+		}
+		for row := 1; row < g.nRows; row++ {
+			if !yield(g.Ray(Pos{Row: row, Col: g.nCols - 1}, BottomLeft)) {
+				return
+			}
+		}
+	}
+}
+
+// Window iterates over the positions and values in the rectangle with rows rows
+// and cols columns whose top-left corner is p, in row-major order. The parts of
+// the rectangle that are out of bounds are skipped.
+func (g *GridOf[T]) Window(p Pos, rows, cols int) iter.Seq2[Pos, T] {
+	return func(yield func(Pos, T) bool) {
+		r := Rect{Min: p, Max: Pos{Row: p.Row + rows, Col: p.Col + cols}}.Intersect(g.Bounds())
+		for row := r.Min.Row; row < r.Max.Row; row++ {
+			start := row * g.stride
+			for col := r.Min.Col; col < r.Max.Col; col++ {
+				if !yield(Pos{Row: row, Col: col}, g.cells[start+col]) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package asciigrid
+
+import (
+	"iter"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// collectSeq2 collects the positions and values from seq, with the values as a
+// string.
+func collectSeq2(seq iter.Seq2[Pos, byte]) ([]Pos, string) {
+	var (
+		ps []Pos
+		bs []byte
+	)
+	for p, b := range seq {
+		ps = append(ps, p)
+		bs = append(bs, b)
+	}
+	return ps, string(bs)
+}
+
+// collectLines collects the values of each line in seq as a string.
+func collectLines(seq iter.Seq[iter.Seq2[Pos, byte]]) []string {
+	var lines []string
+	for line := range seq {
+		_, s := collectSeq2(line)
+		lines = append(lines, s)
+	}
+	return lines
+}
+
+func TestGrid_Ray(t *testing.T) {
+	g := newT(t, "abc\ndef\nghi")
+	for _, tt := range []struct {
+		p    Pos
+		d    Direction
+		want string
+	}{
+		{p: Pos{0, 0}, d: Right, want: "abc"},
+		{p: Pos{0, 0}, d: Down, want: "adg"},
+		{p: Pos{0, 0}, d: BottomRight, want: "aei"},
+		{p: Pos{0, 0}, d: Left, want: "a"},
+		{p: Pos{0, 0}, d: Up, want: "a"},
+		{p: Pos{2, 2}, d: TopLeft, want: "iea"},
+		{p: Pos{1, 2}, d: Left, want: "fed"},
+		{p: Pos{2, 1}, d: Up, want: "heb"},
+		{p: Pos{0, 2}, d: BottomLeft, want: "ceg"},
+		{p: Pos{2, 0}, d: TopRight, want: "gec"},
+		{p: Pos{1, 1}, d: None, want: "e"},
+		{p: Pos{-1, 0}, d: Down, want: ""},
+		{p: Pos{0, 3}, d: Left, want: ""},
+	} {
+		_, got := collectSeq2(g.Ray(tt.p, tt.d))
+		if got != tt.want {
+			t.Errorf("g.Ray(%v, %v) = %q; want %q", tt.p, tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestGrid_Ray_Positions(t *testing.T) {
+	g := newT(t, "abc\ndef\nghi")
+	got, _ := collectSeq2(g.Ray(Pos{Row: 2, Col: 1}, TopRight))
+	want := []Pos{{2, 1}, {1, 2}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("g.Ray(): unexpected positions (-want +got)\n%s", diff)
+	}
+}
+
+func TestGrid_Rows_Cols(t *testing.T) {
+	g := newT(t, "abc\ndef")
+	if diff := cmp.Diff([]string{"abc", "def"}, collectLines(g.Rows())); diff != "" {
+		t.Errorf("g.Rows(): unexpected lines (-want +got)\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"ad", "be", "cf"}, collectLines(g.Cols())); diff != "" {
+		t.Errorf("g.Cols(): unexpected lines (-want +got)\n%s", diff)
+	}
+	// The rows and columns should be the same as those of the old iterators.
+	for row := range g.NRows() {
+		wantPs, want := collect2(g.Row(row))
+		gotPs, got := collectSeq2(g.Ray(Pos{Row: row, Col: 0}, Right))
+		if diff := cmp.Diff(wantPs, gotPs); diff != "" || got != want {
+			t.Errorf("row %d: Ray() = %v, %q; Row() = %v, %q", row, gotPs, got, wantPs, want)
+		}
+	}
+}
+
+func TestGrid_Diagonals(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		want []string
+	}{
+		{s: "", want: nil},
+		{s: "a", want: []string{"a", "a"}},
+		{
+			s: "abc\ndef",
+			want: []string{
+				// Down and to the right.
+				"d", "ae", "bf", "c",
+				// Down and to the left.
+				"a", "bd", "ce", "f",
+			},
+		},
+		{
+			s: "ab\ncd\nef",
+			want: []string{
+				"e", "cf", "ad", "b",
+				"a", "bc", "de", "f",
+			},
+		},
+	} {
+		g := newT(t, tt.s)
+		if diff := cmp.Diff(tt.want, collectLines(g.Diagonals())); diff != "" {
+			t.Errorf("New(%q).Diagonals(): unexpected lines (-want +got)\n%s", tt.s, diff)
+		}
+	}
+}
+
+func TestGrid_Window(t *testing.T) {
+	g := newT(t, "abcd\nefgh\nijkl")
+	for _, tt := range []struct {
+		p          Pos
+		rows, cols int
+		want       string
+	}{
+		{p: Pos{0, 0}, rows: 2, cols: 2, want: "abef"},
+		{p: Pos{1, 1}, rows: 2, cols: 3, want: "fghjkl"},
+		{p: Pos{1, 2}, rows: 5, cols: 5, want: "ghkl"},
+		{p: Pos{-1, -1}, rows: 2, cols: 3, want: "ab"},
+		{p: Pos{0, 0}, rows: 0, cols: 3, want: ""},
+		{p: Pos{3, 0}, rows: 1, cols: 1, want: ""},
+	} {
+		_, got := collectSeq2(g.Window(tt.p, tt.rows, tt.cols))
+		if got != tt.want {
+			t.Errorf("g.Window(%v, %d, %d) = %q; want %q", tt.p, tt.rows, tt.cols, got, tt.want)
+		}
+	}
+}
+
+func TestSeq(t *testing.T) {
+	g := newT(t, "abc\ndef")
+	wantPs, want := collect2(g.Col(1))
+	gotPs, got := collectSeq2(Seq(g.Col(1)))
+	if diff := cmp.Diff(wantPs, gotPs); diff != "" || got != want {
+		t.Errorf("Seq(g.Col(1)) = %v, %q; want %v, %q", gotPs, got, wantPs, want)
+	}
+}
+
+func TestGrid_Ray_Break(t *testing.T) {
+	g := newT(t, "abc\ndef\nghi")
+	var got []byte
+	for _, b := range g.Ray(Pos{Row: 0, Col: 0}, Right) {
+		got = append(got, b)
+		if b == 'b' {
+			break
+		}
+	}
+	if string(got) != "ab" {
+		t.Errorf("breaking out of g.Ray() gave %q; want %q", got, "ab")
+	}
+}
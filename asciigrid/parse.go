@@ -0,0 +1,89 @@
+package asciigrid
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Option changes how New and ParseBlocks parse their input.
+type Option func(*options)
+
+type options struct {
+	crlf    bool
+	pad     bool
+	padWith byte
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// CRLF makes New accept input with Windows-style "\r\n" line endings, as well
+// as plain "\n". Input with "\r\n" line endings is copied.
+func CRLF() Option {
+	return func(o *options) {
+		o.crlf = true
+	}
+}
+
+// PadRaggedRows makes New accept rows of different lengths. The grid gets as
+// many columns as the longest row, and shorter rows are padded with fill at
+// the end. Since leading and trailing spaces are part of the rows, only blank
+// lines at the start and end of the input are trimmed. The input is always
+// copied.
+func PadRaggedRows(fill byte) Option {
+	return func(o *options) {
+		o.pad = true
+		o.padWith = fill
+	}
+}
+
+// ParseBlocks parses input consisting of several grids separated by blank
+// lines, using the given options for each of them. If any of the grids fail to
+// parse, ParseBlocks returns an error for each of them, saying which block it
+// is and which line of input it starts on.
+//
+// Like New, ParseBlocks doesn't copy the input unless an option requires it.
+func ParseBlocks(input string, opts ...Option) ([]*Grid, error) {
+	o := newOptions(opts)
+	var (
+		grids []*Grid
+		errs  []error
+	)
+	// blockStart is the byte offset of the first line of the current block,
+	// or -1 if we're between blocks.
+	blockStart, blockLine := -1, 0
+	flush := func(end int) {
+		if blockStart == -1 {
+			return
+		}
+		g, err := parse(input[blockStart:end], o)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("asciigrid: block %d starting on line %d: %v", len(grids)+len(errs), blockLine, err))
+		} else {
+			grids = append(grids, g)
+		}
+		blockStart = -1
+	}
+	offset := 0
+	lineNum := 1
+	for line := range strings.Lines(input) {
+		if strings.TrimSpace(line) == "" {
+			flush(offset)
+		} else if blockStart == -1 {
+			blockStart, blockLine = offset, lineNum
+		}
+		offset += len(line)
+		lineNum++
+	}
+	flush(len(input))
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return grids, nil
+}
@@ -0,0 +1,156 @@
+package asciigrid
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNew_CRLF(t *testing.T) {
+	for _, s := range []string{
+		"ab\r\ncd",
+		"ab\r\ncd\r\n",
+		"ab\ncd\r\n",
+		"ab\ncd",
+	} {
+		g, err := New(s, CRLF())
+		if err != nil {
+			t.Errorf("New(%q, CRLF()) err = %v; want nil", s, err)
+			continue
+		}
+		if got, want := g.String(), "ab\ncd"; got != want {
+			t.Errorf("New(%q, CRLF()).String() = %q; want %q", s, got, want)
+		}
+	}
+	if _, err := New("ab\r\ncd\r\n"); err == nil {
+		t.Errorf("New(%q) without CRLF() succeeded unexpectedly", "ab\r\ncd\r\n")
+	}
+}
+
+func TestNew_PadRaggedRows(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		want string
+	}{
+		{s: "", want: ""},
+		{s: "\n\n", want: ""},
+		{s: "#", want: "#"},
+		{s: "#\n##\n###", want: "#  \n## \n###"},
+		{s: "\n  #\n #\n\n", want: "  #\n # "},
+		{s: "ab\r\nc\r\n", want: "ab\nc "},
+	} {
+		g, err := New(tt.s, PadRaggedRows(' '), CRLF())
+		if err != nil {
+			t.Errorf("New(%q, PadRaggedRows(' '), CRLF()) err = %v; want nil", tt.s, err)
+			continue
+		}
+		if got := g.String(); got != tt.want {
+			t.Errorf("New(%q, PadRaggedRows(' '), CRLF()).String() = %q; want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestNew_PadRaggedRows_Set(t *testing.T) {
+	g := MustNew("ab\nc", PadRaggedRows('.'))
+	g.Set(Pos{Row: 1, Col: 1}, '#')
+	if got, want := g.String(), "ab\nc#"; got != want {
+		t.Errorf("after g.Set(): g.String() = %q; want %q", got, want)
+	}
+}
+
+func TestParseBlocks(t *testing.T) {
+	input := `
+#.#
+...
+
+ab
+cd
+ef
+  
+##
+
+
+.
+`
+	grids, err := ParseBlocks(input)
+	if err != nil {
+		t.Fatalf("ParseBlocks() err = %v; want nil", err)
+	}
+	var got []string
+	for _, g := range grids {
+		got = append(got, g.String())
+	}
+	want := []string{"#.#\n...", "ab\ncd\nef", "##", "."}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ParseBlocks(): unexpected grids (-want +got)\n%s", diff)
+	}
+}
+
+func TestParseBlocks_Error(t *testing.T) {
+	input := "##\n##\n\n#\n##\n\n..\n\n.\n..\n"
+	_, err := ParseBlocks(input)
+	if err == nil {
+		t.Fatal("ParseBlocks() succeeded unexpectedly")
+	}
+	want := "asciigrid: block 1 starting on line 4: row 1 has 2 columns, expected 1\n" +
+		"asciigrid: block 3 starting on line 9: row 1 has 2 columns, expected 1"
+	if got := err.Error(); got != want {
+		t.Errorf("ParseBlocks() err = %q; want %q", got, want)
+	}
+	if _, err := ParseBlocks(input, PadRaggedRows('.')); err != nil {
+		t.Errorf("ParseBlocks(PadRaggedRows('.')) err = %v; want nil", err)
+	}
+}
+
+func TestFind(t *testing.T) {
+	g := newT(t, "S..#\n.#.E\n#..#")
+	for _, tt := range []struct {
+		b      byte
+		want   Pos
+		wantOK bool
+	}{
+		{b: 'S', want: Pos{Row: 0, Col: 0}, wantOK: true},
+		{b: 'E', want: Pos{Row: 1, Col: 3}, wantOK: true},
+		{b: '#', want: Pos{Row: 0, Col: 3}, wantOK: true},
+		{b: 'X', wantOK: false},
+		// The newlines between rows are not part of the grid.
+		{b: '\n', wantOK: false},
+	} {
+		got, ok := Find(g, tt.b)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("Find(g, %q) = %v, %v; want %v, %v", tt.b, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestFindAll(t *testing.T) {
+	g := newT(t, "S..#\n.#.E\n#..#")
+	want := []Pos{{0, 3}, {1, 1}, {2, 0}, {2, 3}}
+	if diff := cmp.Diff(want, slices.Collect(FindAll(g, '#'))); diff != "" {
+		t.Errorf("FindAll(g, '#'): unexpected result (-want +got)\n%s", diff)
+	}
+	// Grids of other types take the slow path.
+	ints := Map(g, func(b byte) int {
+		if b == '#' {
+			return 1
+		}
+		return 0
+	})
+	if diff := cmp.Diff(want, slices.Collect(FindAll(ints, 1))); diff != "" {
+		t.Errorf("FindAll(ints, 1): unexpected result (-want +got)\n%s", diff)
+	}
+}
+
+func TestMustFind(t *testing.T) {
+	g := newT(t, "S..\n..E")
+	if got, want := MustFind(g, 'E'), (Pos{Row: 1, Col: 2}); got != want {
+		t.Errorf("MustFind(g, 'E') = %v; want %v", got, want)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("MustFind(g, 'X') did not panic")
+		}
+	}()
+	MustFind(g, 'X')
+}
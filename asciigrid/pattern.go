@@ -0,0 +1,278 @@
+package asciigrid
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"iter"
+	"slices"
+	"strconv"
+)
+
+// Orientation is one of the eight ways a grid can be rotated and flipped. The
+// orientations are numbered in the same order as GridOf.Orientations yields
+// them.
+type Orientation int
+
+const (
+	// Identity leaves the grid as it is.
+	Identity Orientation = iota
+	// Rotated90 is the grid rotated 90 degrees clockwise.
+	Rotated90
+	// Rotated180 is the grid rotated 180 degrees.
+	Rotated180
+	// Rotated270 is the grid rotated 270 degrees clockwise.
+	Rotated270
+	// Flipped is the grid mirrored horizontally.
+	Flipped
+	// FlippedRotated90 is the grid mirrored horizontally and then rotated 90
+	// degrees clockwise.
+	FlippedRotated90
+	// FlippedRotated180 is the grid mirrored horizontally and then rotated 180
+	// degrees, which is the same as mirroring it vertically.
+	FlippedRotated180
+	// FlippedRotated270 is the grid mirrored horizontally and then rotated 270
+	// degrees clockwise.
+	FlippedRotated270
+
+	numOrientations = 8
+)
+
+func (o Orientation) String() string {
+	switch o {
+	case Identity:
+		return "Identity"
+	case Rotated90:
+		return "Rotated90"
+	case Rotated180:
+		return "Rotated180"
+	case Rotated270:
+		return "Rotated270"
+	case Flipped:
+		return "Flipped"
+	case FlippedRotated90:
+		return "FlippedRotated90"
+	case FlippedRotated180:
+		return "FlippedRotated180"
+	case FlippedRotated270:
+		return "FlippedRotated270"
+	default:
+		return "Orientation(" + strconv.FormatInt(int64(o), 10) + ")"
+	}
+}
+
+var (
+	// Rotations holds the four orientations that only rotate.
+	Rotations = []Orientation{Identity, Rotated90, Rotated180, Rotated270}
+	// AllOrientations holds all eight orientations.
+	AllOrientations = []Orientation{Identity, Rotated90, Rotated180, Rotated270, Flipped, FlippedRotated90, FlippedRotated180, FlippedRotated270}
+)
+
+// PatternOf is a small template to look for in a grid, where some cells are
+// wildcards that match any value. Use GridOf.Match to find where it occurs.
+type PatternOf[T any] struct {
+	variants [numOrientations]patternVariant[T]
+	eq       func(a, b T) bool
+}
+
+// Pattern is a pattern of bytes, typically parsed by NewPattern.
+type Pattern = PatternOf[byte]
+
+// patternVariant is a pattern in one orientation.
+type patternVariant[T any] struct {
+	nRows, nCols int
+	// cells holds the non-wildcard cells, relative to the top-left corner.
+	cells []patternCell[T]
+	// canonical is the first orientation whose variant is equal to this one.
+	canonical Orientation
+}
+
+type patternCell[T any] struct {
+	p Pos
+	v T
+}
+
+// NewPattern parses s into a Pattern, where the wildcard byte matches anything.
+// Rows may have different lengths, in which case the shorter ones are padded
+// with wildcards. Leading spaces are kept, so that space can be the wildcard.
+// A pattern with nothing but wildcards is an error.
+func NewPattern(s string, wildcard byte) (*Pattern, error) {
+	g, err := New(s, PadRaggedRows(wildcard))
+	if err != nil {
+		return nil, err
+	}
+	return NewPatternOf(g, wildcard)
+}
+
+// MustPattern is like NewPattern but panics on error.
+func MustPattern(s string, wildcard byte) *Pattern {
+	p, err := NewPattern(s, wildcard)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// NewPatternOf makes a pattern out of the grid g, where cells holding wildcard
+// match anything. A pattern with nothing but wildcards is an error.
+func NewPatternOf[T comparable](g *GridOf[T], wildcard T) (*PatternOf[T], error) {
+	p := &PatternOf[T]{eq: func(a, b T) bool { return a == b }}
+	var grids []*GridOf[T]
+	for g2 := range g.Orientations() {
+		o := len(grids)
+		v := &p.variants[o]
+		v.nRows, v.nCols = g2.nRows, g2.nCols
+		for q, x := range g2.All() {
+			if x != wildcard {
+				v.cells = append(v.cells, patternCell[T]{p: q, v: x})
+			}
+		}
+		if len(v.cells) == 0 {
+			return nil, errors.New("asciigrid: pattern has no cells that aren't wildcards")
+		}
+		v.canonical = Orientation(o)
+		for i, prev := range grids {
+			if Equal(g2, prev) {
+				v.canonical = Orientation(i)
+				break
+			}
+		}
+		grids = append(grids, g2)
+	}
+	return p, nil
+}
+
+// Size returns the number of rows and columns of p in orientation o.
+func (p *PatternOf[T]) Size(o Orientation) (rows, cols int) {
+	v := &p.variants[o]
+	return v.nRows, v.nCols
+}
+
+// Cells iterates over the positions covered by the non-wildcard cells of p in
+// orientation o, when its top-left corner is at the position at. Together with
+// Match it can be used to mark the cells of every match.
+func (p *PatternOf[T]) Cells(at Pos, o Orientation) iter.Seq[Pos] {
+	return func(yield func(Pos) bool) {
+		for _, c := range p.variants[o].cells {
+			if !yield(Pos{Row: at.Row + c.p.Row, Col: at.Col + c.p.Col}) {
+				return
+			}
+		}
+	}
+}
+
+// Match iterates over the places where p occurs in g in any of the given
+// orientations. The whole pattern must fit within g, including its wildcards.
+// It yields the position of the top-left corner of the oriented
+// pattern, and the orientation. The matches are yielded in row-major order of
+// their position, and in the order the orientations are given for matches at
+// the same position.
+//
+// If p looks the same in several of the given orientations, like a symmetrical
+// pattern does, only the first of them is used. That way each distinct
+// placement of the pattern is yielded once.
+func (g *GridOf[T]) Match(p *PatternOf[T], orientations []Orientation) iter.Seq2[Pos, Orientation] {
+	return func(yield func(Pos, Orientation) bool) {
+		// matcher is an oriented pattern, with the cells given as offsets in
+		// the cells of g.
+		type matcher struct {
+			o            Orientation
+			nRows, nCols int
+			// offsets holds the offsets of the cells of the pattern from the
+			// top-left corner in g.cells, and values what they must hold.
+			offsets []int
+			values  []T
+			// bytes holds the same as values when g is a grid of bytes.
+			bytes []byte
+		}
+		var (
+			matchers []matcher
+			used     [numOrientations]bool
+		)
+		for _, o := range orientations {
+			v := &p.variants[o]
+			if used[v.canonical] {
+				continue
+			}
+			used[v.canonical] = true
+			m := matcher{o: o, nRows: v.nRows, nCols: v.nCols}
+			for _, c := range v.cells {
+				m.offsets = append(m.offsets, c.p.Row*g.stride+c.p.Col)
+				m.values = append(m.values, c.v)
+			}
+			m.bytes, _ = any(m.values).([]byte)
+			matchers = append(matchers, m)
+		}
+		// Find the matches one row at a time, one matcher at a time, and then
+		// sort the matches in the row by column and the order of the
+		// orientations. That's much faster than trying every matcher at every
+		// position.
+		type match struct {
+			col, i int
+		}
+		var rowMatches []match
+		cells, isBytes := any(g.cells).([]byte)
+		for row := range g.nRows {
+			rowMatches = rowMatches[:0]
+			for i := range matchers {
+				m := &matchers[i]
+				if row+m.nRows > g.nRows || m.nCols > g.nCols {
+					continue
+				}
+				rowStart := row * g.stride
+				lastCol := g.nCols - m.nCols
+				if isBytes {
+					// Use the first cell of the pattern as an anchor, and
+					// only check the rest of it where the anchor matches.
+					anchor := cells[rowStart+m.offsets[0] : rowStart+m.offsets[0]+lastCol+1]
+					for col := 0; col <= lastCol; col++ {
+						j := bytes.IndexByte(anchor[col:], m.bytes[0])
+						if j == -1 {
+							break
+						}
+						col += j
+						if matchBytes(cells[rowStart+col:], m.offsets[1:], m.bytes[1:]) {
+							rowMatches = append(rowMatches, match{col: col, i: i})
+						}
+					}
+				} else {
+					for col := 0; col <= lastCol; col++ {
+						if matchCells(g.cells[rowStart+col:], m.offsets, m.values, p.eq) {
+							rowMatches = append(rowMatches, match{col: col, i: i})
+						}
+					}
+				}
+			}
+			if len(matchers) > 1 {
+				// The matches of each matcher are already sorted by column,
+				// and they were found in the order of the matchers.
+				slices.SortStableFunc(rowMatches, func(a, b match) int { return cmp.Compare(a.col, b.col) })
+			}
+			for _, rm := range rowMatches {
+				if !yield(Pos{Row: row, Col: rm.col}, matchers[rm.i].o) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// matchBytes reports whether cells[offsets[i]] == values[i] for all i.
+func matchBytes(cells []byte, offsets []int, values []byte) bool {
+	for i, off := range offsets {
+		if cells[off] != values[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// matchCells is like matchBytes but compares values with eq.
+func matchCells[T any](cells []T, offsets []int, values []T, eq func(a, b T) bool) bool {
+	for i, off := range offsets {
+		if !eq(cells[off], values[i]) {
+			return false
+		}
+	}
+	return true
+}
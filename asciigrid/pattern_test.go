@@ -0,0 +1,208 @@
+package asciigrid
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.saser.se/adventofgo/aocdata"
+)
+
+// The example from Advent of Code 2024 day 4.
+const wordSearch = `
+MMMSXXMASM
+MSAMXMSMSA
+AMXSXMAAMM
+MSAMASMSMX
+XMASAMXAMM
+XXAMMXXAMA
+SMSMSASXSS
+SAXAMASAAA
+MAMMMXMMMM
+MXMXAXMASX
+`
+
+var (
+	xmas         = MustPattern("XMAS", '.')
+	xmasDiagonal = MustPattern("X...\n.M..\n..A.\n...S", '.')
+	xDashMAS     = MustPattern("M.S\n.A.\nM.S", '.')
+)
+
+func countMatches[T any](g *GridOf[T], orientations []Orientation, ps ...*PatternOf[T]) int {
+	n := 0
+	for _, p := range ps {
+		for range g.Match(p, orientations) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestGrid_Match_WordSearch(t *testing.T) {
+	g := newT(t, wordSearch)
+	if got, want := countMatches(g, AllOrientations, xmas, xmasDiagonal), 18; got != want {
+		t.Errorf("number of XMAS = %d; want %d", got, want)
+	}
+	if got, want := countMatches(g, AllOrientations, xDashMAS), 9; got != want {
+		t.Errorf("number of X-MAS = %d; want %d", got, want)
+	}
+}
+
+func TestGrid_Match_Orientations(t *testing.T) {
+	g := newT(t, `
+ab..
+cd..
+..db
+..ca
+`)
+	p := MustPattern("ab\ncd", '.')
+	type match struct {
+		P Pos
+		O Orientation
+	}
+	for _, tt := range []struct {
+		orientations []Orientation
+		want         []match
+	}{
+		{orientations: []Orientation{Identity}, want: []match{{Pos{0, 0}, Identity}}},
+		{orientations: Rotations, want: []match{{Pos{0, 0}, Identity}}},
+		{orientations: AllOrientations, want: []match{{Pos{0, 0}, Identity}, {Pos{2, 2}, FlippedRotated90}}},
+		{orientations: []Orientation{Flipped, FlippedRotated90}, want: []match{{Pos{2, 2}, FlippedRotated90}}},
+	} {
+		var got []match
+		for q, o := range g.Match(p, tt.orientations) {
+			got = append(got, match{q, o})
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("g.Match(%v): unexpected matches (-want +got)\n%s", tt.orientations, diff)
+		}
+	}
+}
+
+func TestGrid_Match_SeaMonster(t *testing.T) {
+	// The sea monster from Advent of Code 2020 day 20, where spaces are
+	// wildcards.
+	monster := MustPattern(`
+                  # 
+#    ##    ##    ###
+ #  #  #  #  #  #   
+`, ' ')
+	// A monster upside down among some noise, marked with O.
+	const marked = `
+.#.#...#.###...#.##.O#..
+#.O.##.OO#.#.OO.##.OOO##
+..#O.#O#.O##O..O.#O##.##
+`
+	g := newT(t, strings.ReplaceAll(marked, "O", "#"))
+	n := 0
+	for p, o := range g.Match(monster, AllOrientations) {
+		n++
+		if rows, cols := monster.Size(o); rows != 3 || cols != 20 {
+			t.Errorf("monster at %v has size %dx%d; want 3x20", p, rows, cols)
+		}
+		for q := range monster.Cells(p, o) {
+			g.Set(q, 'O')
+		}
+	}
+	if got, want := n, 1; got != want {
+		t.Errorf("found %d monsters; want %d", got, want)
+	}
+	if got, want := g.String(), strings.TrimSpace(marked); got != want {
+		t.Errorf("grid with monsters marked:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// bruteMatch finds the matches of the pattern grid pg, where '.' is a wildcard,
+// by checking every orientation of pg at every position.
+func bruteMatch(g, pg *Grid, orientations []Orientation) map[Pos]int {
+	var variants []*Grid
+	for v := range pg.Orientations() {
+		variants = append(variants, v)
+	}
+	matches := make(map[Pos]int)
+	// distinct holds the variants that have been checked, so that
+	// symmetrical patterns only match once.
+	var distinct []*Grid
+outer:
+	for _, o := range orientations {
+		v := variants[o]
+		for _, d := range distinct {
+			if Equal(v, d) {
+				continue outer
+			}
+		}
+		distinct = append(distinct, v)
+		for p := range g.Bounds().All() {
+			ok := true
+			for q, b := range v.All() {
+				q = Pos{Row: p.Row + q.Row, Col: p.Col + q.Col}
+				if !g.InBounds(q) || b != '.' && g.Get(q) != b {
+					ok = false
+					break
+				}
+			}
+			if ok {
+				matches[p]++
+			}
+		}
+	}
+	return matches
+}
+
+func randomGrid(r *rand.Rand, rows, cols int, alphabet string) *Grid {
+	g := Make(rows, cols, byte(0))
+	for p := range g.Bounds().All() {
+		g.Set(p, alphabet[r.IntN(len(alphabet))])
+	}
+	return g
+}
+
+func TestGrid_Match_BruteForce(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 500 {
+		g := randomGrid(r, 1+r.IntN(10), 1+r.IntN(10), "ab")
+		pg := randomGrid(r, 1+r.IntN(3), 1+r.IntN(3), "ab.")
+		pg.Set(Pos{}, 'a') // Make sure there's at least one cell.
+		p, err := NewPatternOf(pg, '.')
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, orientations := range [][]Orientation{AllOrientations, Rotations, {Identity, Flipped}} {
+			got := make(map[Pos]int)
+			for q := range g.Match(p, orientations) {
+				got[q]++
+			}
+			if diff := cmp.Diff(bruteMatch(g, pg, orientations), got); diff != "" {
+				t.Fatalf("grid:\n%v\npattern:\n%v\ng.Match(%v): unexpected matches (-want +got)\n%s", g, pg, orientations, diff)
+			}
+		}
+	}
+}
+
+func TestNewPattern_Error(t *testing.T) {
+	for _, s := range []string{"", "...", "..\n."} {
+		if _, err := NewPattern(s, '.'); err == nil {
+			t.Errorf("NewPattern(%q) succeeded unexpectedly", s)
+		}
+	}
+}
+
+func BenchmarkGrid_Match(b *testing.B) {
+	// The input for Advent of Code 2024 day 4 is a 140x140 grid.
+	g := MustNew(aocdata.InputT(b, 2024, 4))
+	for _, bb := range []struct {
+		name     string
+		patterns []*Pattern
+	}{
+		{name: "XMAS", patterns: []*Pattern{xmas, xmasDiagonal}},
+		{name: "X-MAS", patterns: []*Pattern{xDashMAS}},
+		{name: "All", patterns: []*Pattern{xmas, xmasDiagonal, xDashMAS}},
+	} {
+		b.Run(bb.name, func(b *testing.B) {
+			for b.Loop() {
+				countMatches(g, AllOrientations, bb.patterns...)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package asciigrid
+
+import (
+	"fmt"
+	"iter"
+)
+
+// Rect is a rectangle of positions in a grid. It contains the positions p where
+// Min.Row <= p.Row < Max.Row and Min.Col <= p.Col < Max.Col; i.e., Min is
+// inclusive and Max is exclusive. A Rect is empty if it contains no positions.
+type Rect struct {
+	Min, Max Pos
+}
+
+// RectOf returns the smallest Rect that contains all the given positions. If
+// no positions are given, RectOf returns the zero Rect, which is empty.
+func RectOf(ps ...Pos) Rect {
+	if len(ps) == 0 {
+		return Rect{}
+	}
+	r := Rect{Min: ps[0], Max: ps[0]}
+	for _, p := range ps[1:] {
+		r.Min.Row = min(r.Min.Row, p.Row)
+		r.Min.Col = min(r.Min.Col, p.Col)
+		r.Max.Row = max(r.Max.Row, p.Row)
+		r.Max.Col = max(r.Max.Col, p.Col)
+	}
+	r.Max.Row++
+	r.Max.Col++
+	return r
+}
+
+// NRows is the number of rows covered by the rectangle.
+func (r Rect) NRows() int {
+	return max(r.Max.Row-r.Min.Row, 0)
+}
+
+// NCols is the number of columns covered by the rectangle.
+func (r Rect) NCols() int {
+	return max(r.Max.Col-r.Min.Col, 0)
+}
+
+// Empty reports whether the rectangle contains no positions.
+func (r Rect) Empty() bool {
+	return r.NRows() == 0 || r.NCols() == 0
+}
+
+// Contains reports whether p is in the rectangle.
+func (r Rect) Contains(p Pos) bool {
+	return p.Row >= r.Min.Row && p.Row < r.Max.Row && p.Col >= r.Min.Col && p.Col < r.Max.Col
+}
+
+// ContainsRect reports whether every position in s is also in r. An empty
+// rectangle is contained in every rectangle.
+func (r Rect) ContainsRect(s Rect) bool {
+	if s.Empty() {
+		return true
+	}
+	return s.Min.Row >= r.Min.Row && s.Max.Row <= r.Max.Row && s.Min.Col >= r.Min.Col && s.Max.Col <= r.Max.Col
+}
+
+// Intersect returns the largest rectangle contained in both r and s. If they
+// don't overlap, Intersect returns the zero Rect.
+func (r Rect) Intersect(s Rect) Rect {
+	i := Rect{
+		Min: Pos{Row: max(r.Min.Row, s.Min.Row), Col: max(r.Min.Col, s.Min.Col)},
+		Max: Pos{Row: min(r.Max.Row, s.Max.Row), Col: min(r.Max.Col, s.Max.Col)},
+	}
+	if i.Empty() {
+		return Rect{}
+	}
+	return i
+}
+
+// Union returns the smallest rectangle that contains both r and s. Empty
+// rectangles are ignored.
+func (r Rect) Union(s Rect) Rect {
+	if r.Empty() {
+		return s
+	}
+	if s.Empty() {
+		return r
+	}
+	return Rect{
+		Min: Pos{Row: min(r.Min.Row, s.Min.Row), Col: min(r.Min.Col, s.Min.Col)},
+		Max: Pos{Row: max(r.Max.Row, s.Max.Row), Col: max(r.Max.Col, s.Max.Col)},
+	}
+}
+
+// All iterates over all positions in the rectangle in row-major order.
+func (r Rect) All() iter.Seq[Pos] {
+	return func(yield func(Pos) bool) {
+		for row := r.Min.Row; row < r.Max.Row; row++ {
+			for col := r.Min.Col; col < r.Max.Col; col++ {
+				if !yield(Pos{Row: row, Col: col}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (r Rect) String() string {
+	return fmt.Sprintf("[%v, %v)", r.Min, r.Max)
+}
+
+// Bounds returns the rectangle covering all positions in the grid.
+func (g *GridOf[T]) Bounds() Rect {
+	return Rect{Max: Pos{Row: g.nRows, Col: g.nCols}}
+}
@@ -0,0 +1,88 @@
+package asciigrid
+
+import (
+	"testing"
+)
+
+func TestRectOf(t *testing.T) {
+	for _, tt := range []struct {
+		ps   []Pos
+		want Rect
+	}{
+		{
+			ps:   nil,
+			want: Rect{},
+		},
+		{
+			ps:   []Pos{{Row: 2, Col: 3}},
+			want: Rect{Min: Pos{Row: 2, Col: 3}, Max: Pos{Row: 3, Col: 4}},
+		},
+		{
+			ps:   []Pos{{Row: 2, Col: 3}, {Row: -1, Col: 5}, {Row: 0, Col: 0}},
+			want: Rect{Min: Pos{Row: -1, Col: 0}, Max: Pos{Row: 3, Col: 6}},
+		},
+	} {
+		got := RectOf(tt.ps...)
+		if got != tt.want {
+			t.Errorf("RectOf(%v) = %v; want %v", tt.ps, got, tt.want)
+		}
+		for _, p := range tt.ps {
+			if !got.Contains(p) {
+				t.Errorf("RectOf(%v) = %v, which does not contain %v", tt.ps, got, p)
+			}
+		}
+	}
+}
+
+func TestRect_Intersect_Union(t *testing.T) {
+	a := Rect{Min: Pos{Row: 0, Col: 0}, Max: Pos{Row: 4, Col: 4}}
+	b := Rect{Min: Pos{Row: 2, Col: 3}, Max: Pos{Row: 6, Col: 5}}
+	c := Rect{Min: Pos{Row: 10, Col: 10}, Max: Pos{Row: 11, Col: 11}}
+	for _, tt := range []struct {
+		r, s          Rect
+		wantIntersect Rect
+		wantUnion     Rect
+	}{
+		{
+			r:             a,
+			s:             b,
+			wantIntersect: Rect{Min: Pos{Row: 2, Col: 3}, Max: Pos{Row: 4, Col: 4}},
+			wantUnion:     Rect{Min: Pos{Row: 0, Col: 0}, Max: Pos{Row: 6, Col: 5}},
+		},
+		{
+			r:             a,
+			s:             c,
+			wantIntersect: Rect{},
+			wantUnion:     Rect{Min: Pos{Row: 0, Col: 0}, Max: Pos{Row: 11, Col: 11}},
+		},
+		{
+			r:             a,
+			s:             Rect{},
+			wantIntersect: Rect{},
+			wantUnion:     a,
+		},
+	} {
+		for _, rs := range [][2]Rect{{tt.r, tt.s}, {tt.s, tt.r}} {
+			if got := rs[0].Intersect(rs[1]); got != tt.wantIntersect {
+				t.Errorf("%v.Intersect(%v) = %v; want %v", rs[0], rs[1], got, tt.wantIntersect)
+			}
+			if got := rs[0].Union(rs[1]); got != tt.wantUnion {
+				t.Errorf("%v.Union(%v) = %v; want %v", rs[0], rs[1], got, tt.wantUnion)
+			}
+		}
+	}
+}
+
+func TestRect_All(t *testing.T) {
+	r := Rect{Min: Pos{Row: 1, Col: -1}, Max: Pos{Row: 3, Col: 2}}
+	n := 0
+	for p := range r.All() {
+		if !r.Contains(p) {
+			t.Errorf("%v.All() yielded %v, which it doesn't contain", r, p)
+		}
+		n++
+	}
+	if got, want := n, r.NRows()*r.NCols(); got != want {
+		t.Errorf("%v.All() yielded %d positions; want %d", r, got, want)
+	}
+}
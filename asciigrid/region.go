@@ -0,0 +1,154 @@
+package asciigrid
+
+// Region is a set of positions in a grid that are connected to each other by
+// steps up, down, left or right.
+type Region struct {
+	// Label identifies the region. For regions returned by Regions, it is the
+	// index of the region in the returned slice.
+	Label int
+	// Cells holds the positions in the region, in the order they were found.
+	Cells []Pos
+
+	// labels holds the label of the region each position belongs to, or -1.
+	// It's shared between all regions found by the same call to Regions.
+	labels *GridOf[int]
+}
+
+// Regions divides g into regions, where two neighboring positions p and q are
+// in the same region if sameRegion(g.Get(p), g.Get(q)) is true. sameRegion
+// should be an equivalence relation, most commonly equality, which divides the
+// grid into areas of the same value.
+//
+// Regions returns the regions in row-major order of their first position, and
+// a grid holding the label of the region each position belongs to.
+func Regions[T any](g *GridOf[T], sameRegion func(a, b T) bool) ([]*Region, *GridOf[int]) {
+	labels := MakeLike(g, -1)
+	var regions []*Region
+	for p := range g.Bounds().All() {
+		if labels.Get(p) != -1 {
+			continue
+		}
+		r := &Region{Label: len(regions), labels: labels}
+		r.fill(p, func(from, to Pos) bool { return sameRegion(g.Get(from), g.Get(to)) })
+		regions = append(regions, r)
+	}
+	return regions, labels
+}
+
+// FloodFill returns the region of positions in g that can be reached from seed,
+// where passable reports whether one can take a step between two neighboring
+// positions. The region always includes seed, and has label 0.
+func (g *GridOf[T]) FloodFill(seed Pos, passable func(from, to Pos) bool) *Region {
+	r := &Region{Label: 0, labels: MakeLike(g, -1)}
+	r.fill(seed, passable)
+	return r
+}
+
+// fill adds the positions reachable from seed to r, as long as they are not
+// already part of another region.
+func (r *Region) fill(seed Pos, passable func(from, to Pos) bool) {
+	r.labels.Set(seed, r.Label)
+	r.Cells = append(r.Cells, seed)
+	for i := 0; i < len(r.Cells); i++ {
+		p := r.Cells[i]
+		for _, n := range r.labels.Neighbors4(p) {
+			if r.labels.Get(n) != -1 || !passable(p, n) {
+				continue
+			}
+			r.labels.Set(n, r.Label)
+			r.Cells = append(r.Cells, n)
+		}
+	}
+}
+
+// Contains reports whether p is in r.
+func (r *Region) Contains(p Pos) bool {
+	return r.labels.InBounds(p) && r.labels.Get(p) == r.Label
+}
+
+// Area returns the number of positions in r.
+func (r *Region) Area() int {
+	return len(r.Cells)
+}
+
+// Perimeter returns the length of the fence that would be needed to surround
+// r, i.e. the number of edges between a position in r and a position outside
+// it. Edges against holes in the region count too.
+func (r *Region) Perimeter() int {
+	n := 0
+	for _, p := range r.Cells {
+		for _, q := range p.Neighbors4Seq() {
+			if !r.Contains(q) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// Sides returns the number of straight sides of r, where a side is any number
+// of perimeter edges in a straight line. Sides of holes in the region count
+// too.
+//
+// The number of sides is the same as the number of corners. Each of the four
+// corners of a position in r is a corner of the region if either neither of
+// the two positions next to it are in r, like the top-right corner of X here:
+//
+//	..
+//	X.
+//
+// or both of them are in r but the diagonal is not, like the top-right corner
+// of X here:
+//
+//	R.
+//	XR
+func (r *Region) Sides() int {
+	n := 0
+	for _, p := range r.Cells {
+		for _, corner := range [...][3]Direction{
+			{Up, TopRight, Right},
+			{Right, BottomRight, Down},
+			{Down, BottomLeft, Left},
+			{Left, TopLeft, Up},
+		} {
+			a := r.Contains(p.Step(corner[0]))
+			diagonal := r.Contains(p.Step(corner[1]))
+			b := r.Contains(p.Step(corner[2]))
+			if !a && !b || a && b && !diagonal {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// Bounds returns the smallest rectangle containing all positions in r.
+func (r *Region) Bounds() Rect {
+	return RectOf(r.Cells...)
+}
+
+// Boundary returns the positions in r that have at least one direct neighbor
+// outside r, in the same order as in Cells.
+func (r *Region) Boundary() []Pos {
+	var boundary []Pos
+	for _, p := range r.Cells {
+		for _, q := range p.Neighbors4Seq() {
+			if !r.Contains(q) {
+				boundary = append(boundary, p)
+				break
+			}
+		}
+	}
+	return boundary
+}
+
+// TouchesEdge reports whether any position in r is on the edge of the grid. A
+// region that doesn't touch the edge is enclosed by other regions.
+func (r *Region) TouchesEdge() bool {
+	for _, p := range r.Cells {
+		if p.Row == 0 || p.Col == 0 || p.Row == r.labels.nRows-1 || p.Col == r.labels.nCols-1 {
+			return true
+		}
+	}
+	return false
+}
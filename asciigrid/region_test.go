@@ -0,0 +1,138 @@
+package asciigrid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRegions(t *testing.T) {
+	// This is an example from 2024 day 12.
+	g := newT(t, strings.TrimSpace(`
+AAAA
+BBCD
+BBCC
+EEEC
+`))
+	regions, labels := Regions(g, func(a, b byte) bool { return a == b })
+	type stats struct {
+		Plant                  byte
+		Area, Perimeter, Sides int
+		Bounds                 Rect
+		TouchesEdge            bool
+	}
+	var got []stats
+	for _, r := range regions {
+		got = append(got, stats{
+			Plant:       g.Get(r.Cells[0]),
+			Area:        r.Area(),
+			Perimeter:   r.Perimeter(),
+			Sides:       r.Sides(),
+			Bounds:      r.Bounds(),
+			TouchesEdge: r.TouchesEdge(),
+		})
+	}
+	want := []stats{
+		{Plant: 'A', Area: 4, Perimeter: 10, Sides: 4, Bounds: Rect{Min: Pos{Row: 0, Col: 0}, Max: Pos{Row: 1, Col: 4}}, TouchesEdge: true},
+		{Plant: 'B', Area: 4, Perimeter: 8, Sides: 4, Bounds: Rect{Min: Pos{Row: 1, Col: 0}, Max: Pos{Row: 3, Col: 2}}, TouchesEdge: true},
+		{Plant: 'C', Area: 4, Perimeter: 10, Sides: 8, Bounds: Rect{Min: Pos{Row: 1, Col: 2}, Max: Pos{Row: 4, Col: 4}}, TouchesEdge: true},
+		{Plant: 'D', Area: 1, Perimeter: 4, Sides: 4, Bounds: Rect{Min: Pos{Row: 1, Col: 3}, Max: Pos{Row: 2, Col: 4}}, TouchesEdge: true},
+		{Plant: 'E', Area: 3, Perimeter: 8, Sides: 4, Bounds: Rect{Min: Pos{Row: 3, Col: 0}, Max: Pos{Row: 4, Col: 3}}, TouchesEdge: true},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Regions() returned unexpected regions (-want +got)\n%s", diff)
+	}
+	for p, label := range labels.All() {
+		if !regions[label].Contains(p) {
+			t.Errorf("labels.Get(%v) = %v, but regions[%v] doesn't contain %v", p, label, label, p)
+		}
+	}
+}
+
+func TestRegions_Holes(t *testing.T) {
+	// This is an example from 2024 day 12, where the O region has holes in it.
+	g := newT(t, strings.TrimSpace(`
+OOOOO
+OXOXO
+OOOOO
+OXOXO
+OOOOO
+`))
+	regions, _ := Regions(g, func(a, b byte) bool { return a == b })
+	if got, want := len(regions), 5; got != want {
+		t.Fatalf("len(Regions()) = %v; want %v", got, want)
+	}
+	o := regions[0]
+	if got, want := o.Area(), 21; got != want {
+		t.Errorf("O region: Area() = %v; want %v", got, want)
+	}
+	if got, want := o.Perimeter(), 36; got != want {
+		t.Errorf("O region: Perimeter() = %v; want %v", got, want)
+	}
+	if got, want := o.Sides(), 20; got != want {
+		t.Errorf("O region: Sides() = %v; want %v", got, want)
+	}
+	if got, want := len(o.Boundary()), 20; got != want {
+		t.Errorf("O region: len(Boundary()) = %v; want %v", got, want)
+	}
+	for _, x := range regions[1:] {
+		if x.TouchesEdge() {
+			t.Errorf("X region at %v: TouchesEdge() = true; want false", x.Cells[0])
+		}
+	}
+}
+
+func TestRegion_Boundary(t *testing.T) {
+	g := newT(t, strings.TrimSpace(`
+.....
+.###.
+.###.
+.###.
+.....
+`))
+	regions, labels := Regions(g, func(a, b byte) bool { return a == b })
+	inner := regions[labels.Get(Pos{Row: 2, Col: 2})]
+	want := newT(t, strings.TrimSpace(`
+.....
+.###.
+.#.#.
+.###.
+.....
+`))
+	got := MakeLike(g, byte('.'))
+	for _, p := range inner.Boundary() {
+		got.Set(p, '#')
+	}
+	if diff := cmp.Diff(want.String(), got.String()); diff != "" {
+		t.Errorf("Boundary() returned unexpected positions (-want +got)\n%s", diff)
+	}
+}
+
+func TestGrid_FloodFill(t *testing.T) {
+	// Steps can only be taken downhill, i.e. to a lower or equal digit.
+	g := newT(t, strings.TrimSpace(`
+9876
+9115
+9224
+9993
+`))
+	downhill := func(from, to Pos) bool { return g.Get(to) <= g.Get(from) }
+	r := g.FloodFill(Pos{Row: 0, Col: 2}, downhill)
+	want := newT(t, strings.TrimSpace(`
+..##
+.###
+.###
+...#
+`))
+	got := MakeLike(g, byte('.'))
+	for _, p := range r.Cells {
+		got.Set(p, '#')
+	}
+	if diff := cmp.Diff(want.String(), got.String()); diff != "" {
+		t.Errorf("FloodFill() returned unexpected region (-want +got)\n%s", diff)
+	}
+	if got, want := r.Area(), 9; got != want {
+		t.Errorf("FloodFill().Area() = %v; want %v", got, want)
+	}
+}
@@ -0,0 +1,212 @@
+package asciigrid
+
+import (
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Color is a color to draw cells with in a terminal.
+type Color int
+
+const (
+	// NoColor draws cells in the terminal's default color.
+	NoColor Color = iota
+	Red
+	Green
+	Yellow
+	Blue
+	Magenta
+	Cyan
+	Gray
+)
+
+// ansiColors holds the ANSI escape codes for setting the foreground color.
+var ansiColors = [...]string{
+	NoColor: "\x1b[0m",
+	Red:     "\x1b[31m",
+	Green:   "\x1b[32m",
+	Yellow:  "\x1b[33m",
+	Blue:    "\x1b[34m",
+	Magenta: "\x1b[35m",
+	Cyan:    "\x1b[36m",
+	Gray:    "\x1b[90m",
+}
+
+// Overlay is a set of positions to draw on top of a grid with the same glyph and
+// color.
+type Overlay struct {
+	// Cells iterates over the positions to draw. Positions out of bounds are
+	// ignored.
+	Cells iter.Seq[Pos]
+	// Glyph is the byte to draw at the positions, or 0 to keep what is in the
+	// grid.
+	Glyph byte
+	// Color is the color to draw the positions in.
+	Color Color
+	// glyphs, if not nil, overrides Glyph for individual positions.
+	glyphs map[Pos]byte
+}
+
+// Points returns an overlay that draws the given positions with glyph and
+// color c.
+func Points(ps iter.Seq[Pos], glyph byte, c Color) Overlay {
+	return Overlay{
+		Cells: ps,
+		Glyph: glyph,
+		Color: c,
+	}
+}
+
+// Path returns an overlay that draws a path in color c. Each position in the
+// path is drawn as an arrow pointing to the next one, one of '^', '>', 'v' and
+// '<', or '*' if the next one isn't a direct neighbor. The last position keeps
+// what is in the grid, but is drawn in color c.
+func Path(path []Pos, c Color) Overlay {
+	arrows := [...]byte{Up: '^', Right: '>', Down: 'v', Left: '<'}
+	glyphs := make(map[Pos]byte, len(path))
+	for i := 0; i+1 < len(path); i++ {
+		glyph := byte('*')
+		for d, q := range path[i].Neighbors4Seq() {
+			if q == path[i+1] {
+				glyph = arrows[d]
+				break
+			}
+		}
+		glyphs[path[i]] = glyph
+	}
+	o := Points(slices.Values(path), 0, c)
+	o.glyphs = glyphs
+	return o
+}
+
+// Renderer draws grids as text, optionally with ANSI escape codes for colors.
+// Without them, the output is deterministic plain text that is suitable for
+// golden tests.
+type Renderer struct {
+	// ANSI enables ANSI escape codes: colors in rendered grids, and clearing the
+	// screen between frames in Play.
+	ANSI bool
+}
+
+// Render draws g with the given overlays on top of it, in order, so that
+// later overlays cover earlier ones. Like g.String(), the output has no
+// trailing newline.
+func (r Renderer) Render(g *Grid, overlays ...Overlay) string {
+	glyphs := g.Clone()
+	colors := MakeLike(g, NoColor)
+	for _, o := range overlays {
+		for p := range o.Cells {
+			if !g.InBounds(p) {
+				continue
+			}
+			glyph := o.Glyph
+			if b, ok := o.glyphs[p]; ok {
+				glyph = b
+			}
+			if glyph != 0 {
+				glyphs.Set(p, glyph)
+			}
+			colors.Set(p, o.Color)
+		}
+	}
+	var sb strings.Builder
+	for row := range g.nRows {
+		if row > 0 {
+			sb.WriteByte('\n')
+		}
+		r.writeRow(&sb, glyphs, colors, row)
+	}
+	return sb.String()
+}
+
+// writeRow writes a row of glyphs to sb, colored according to colors if ANSI
+// escape codes are enabled.
+func (r Renderer) writeRow(sb *strings.Builder, glyphs *Grid, colors *GridOf[Color], row int) {
+	current := NoColor
+	for col := range glyphs.nCols {
+		p := Pos{Row: row, Col: col}
+		c := colors.Get(p)
+		if uint(c) >= uint(len(ansiColors)) {
+			// Colors without an escape code are drawn without color.
+			c = NoColor
+		}
+		if r.ANSI && c != current {
+			sb.WriteString(ansiColors[c])
+			current = c
+		}
+		sb.WriteByte(glyphs.Get(p))
+	}
+	if current != NoColor {
+		sb.WriteString(ansiColors[NoColor])
+	}
+}
+
+// Diff draws a and b side by side, followed by a mask that is '*' where they
+// differ and '.' where they are equal. With ANSI escape codes enabled, the
+// cells that differ are also drawn in red in a and green in b. The grids may
+// have different sizes, in which case the positions that are only in one of
+// them count as different and are blank in the other.
+func (r Renderer) Diff(a, b *Grid) string {
+	rows := max(a.nRows, b.nRows)
+	cols := max(a.nCols, b.nCols)
+	pad := func(g *Grid) *Grid {
+		p := Make(rows, cols, byte(' '))
+		p.Paste(g, Pos{})
+		return p
+	}
+	pa, pb := pad(a), pad(b)
+	mask := Make(rows, cols, byte('.'))
+	colorsA := MakeLike(mask, NoColor)
+	colorsB := MakeLike(mask, NoColor)
+	for p := range mask.Bounds().All() {
+		if a.InBounds(p) != b.InBounds(p) || pa.Get(p) != pb.Get(p) {
+			mask.Set(p, '*')
+			colorsA.Set(p, Red)
+			colorsB.Set(p, Green)
+		}
+	}
+	noColors := MakeLike(mask, NoColor)
+	var sb strings.Builder
+	for row := range rows {
+		if row > 0 {
+			sb.WriteByte('\n')
+		}
+		r.writeRow(&sb, pa, colorsA, row)
+		sb.WriteString(" | ")
+		r.writeRow(&sb, pb, colorsB, row)
+		sb.WriteString(" | ")
+		r.writeRow(&sb, mask, noColors, row)
+	}
+	return sb.String()
+}
+
+// Play writes frames to w one at a time, waiting delay after each of them, to
+// replay a simulation step by step. With ANSI escape codes enabled, the screen
+// is cleared before each frame so that it's drawn in the same place.
+// Otherwise the frames are separated by a blank line.
+func (r Renderer) Play(w io.Writer, frames iter.Seq[string], delay time.Duration) error {
+	first := true
+	for frame := range frames {
+		var err error
+		switch {
+		case r.ANSI:
+			_, err = fmt.Fprintf(w, "\x1b[H\x1b[2J%s\n", frame)
+		case first:
+			_, err = fmt.Fprintf(w, "%s\n", frame)
+		default:
+			_, err = fmt.Fprintf(w, "\n%s\n", frame)
+		}
+		if err != nil {
+			return err
+		}
+		first = false
+		if delay > 0 {
+			time.Sleep(delay)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package asciigrid
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var update = flag.Bool("update", false, "Update the golden files in testdata.")
+
+// checkGolden compares got with the contents of testdata/name, or overwrites
+// the file with got if the -update flag is set.
+func checkGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	if diff := cmp.Diff(string(want), got); diff != "" {
+		t.Errorf("output differs from %s (-want +got)\n%s", path, diff)
+	}
+}
+
+const renderMaze = `
+#########
+#S..#...#
+#.#.#.#.#
+#.#...#E#
+#########
+`
+
+func renderPath() []Pos {
+	return []Pos{
+		{1, 1}, {1, 2}, {1, 3}, {2, 3}, {3, 3}, {3, 4}, {3, 5},
+		{2, 5}, {1, 5}, {1, 6}, {1, 7}, {2, 7}, {3, 7},
+	}
+}
+
+func TestRenderer_Render(t *testing.T) {
+	g := newT(t, renderMaze)
+	overlays := []Overlay{
+		Path(renderPath(), Green),
+		Points(slices.Values([]Pos{{2, 1}, {3, 1}, {0, 0}, {-1, 5}}), 'o', Yellow),
+	}
+	for _, tt := range []struct {
+		name   string
+		r      Renderer
+		golden string
+	}{
+		{name: "Plain", r: Renderer{}, golden: "render.golden"},
+		{name: "ANSI", r: Renderer{ANSI: true}, golden: "render_ansi.golden"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			checkGolden(t, tt.golden, tt.r.Render(g, overlays...))
+		})
+	}
+}
+
+func TestRenderer_Render_NoOverlays(t *testing.T) {
+	g := newT(t, renderMaze)
+	for _, r := range []Renderer{{}, {ANSI: true}} {
+		if got, want := r.Render(g), g.String(); got != want {
+			t.Errorf("%+v.Render(g) = %q; want %q", r, got, want)
+		}
+	}
+}
+
+func TestRenderer_Render_UnknownColor(t *testing.T) {
+	g := newT(t, "...\n...")
+	r := Renderer{ANSI: true}
+	got := r.Render(g, Points(slices.Values([]Pos{{0, 1}}), '#', Color(100)))
+	if want := ".#.\n..."; got != want {
+		t.Errorf("Render() with an unknown color = %q; want %q", got, want)
+	}
+}
+
+func TestRenderer_Diff(t *testing.T) {
+	a := newT(t, "#..\n.#.\n..#")
+	b := newT(t, "#..\n.O.\n..#\n...")
+	for _, tt := range []struct {
+		name   string
+		r      Renderer
+		golden string
+	}{
+		{name: "Plain", r: Renderer{}, golden: "diff.golden"},
+		{name: "ANSI", r: Renderer{ANSI: true}, golden: "diff_ansi.golden"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			checkGolden(t, tt.golden, tt.r.Diff(a, b))
+		})
+	}
+}
+
+func TestRenderer_Play(t *testing.T) {
+	g := newT(t, renderMaze)
+	path := renderPath()
+	frames := func(yield func(string) bool) {
+		for i := range path {
+			if !yield(Renderer{}.Render(g, Path(path[:i+1], NoColor))) {
+				return
+			}
+		}
+	}
+	for _, tt := range []struct {
+		name   string
+		r      Renderer
+		golden string
+	}{
+		{name: "Plain", r: Renderer{}, golden: "play.golden"},
+		{name: "ANSI", r: Renderer{ANSI: true}, golden: "play_ansi.golden"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			if err := tt.r.Play(&sb, frames, 0); err != nil {
+				t.Fatal(err)
+			}
+			checkGolden(t, tt.golden, sb.String())
+		})
+	}
+}
+
+func TestPath_Glyphs(t *testing.T) {
+	g := Make(3, 3, byte('.'))
+	got := Renderer{}.Render(g, Path([]Pos{{0, 0}, {0, 1}, {1, 1}, {2, 2}, {2, 1}}, NoColor))
+	want := strings.Join([]string{
+		">v.",
+		".*.",
+		"..<",
+	}, "\n")
+	if got != want {
+		t.Errorf("Render() = %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,100 @@
+package asciigrid
+
+import "iter"
+
+// FirstAlong returns the first position beyond p in direction d for which stop
+// returns true, and whether there was one before leaving the grid. It is the
+// building block of line-of-sight puzzles, like finding the first tree that
+// is at least as tall as the one at p.
+func (g *GridOf[T]) FirstAlong(p Pos, d Direction, stop func(p Pos, v T) bool) (Pos, bool) {
+	if d == None {
+		return Pos{}, false
+	}
+	for q, v := range g.Ray(p.Step(d), d) {
+		if stop(q, v) {
+			return q, true
+		}
+	}
+	return Pos{}, false
+}
+
+// Sightlines is a lookup table of what can be seen from each position in a
+// grid when looking in each of the eight directions, where the view is blocked
+// by the first position that isn't empty. Building the table takes time
+// proportional to the size of the grid, after which each lookup takes constant
+// time.
+//
+// The table is a snapshot of which positions were empty when it was built. It is
+// still valid after changing the values in the grid, as long as empty
+// positions stay empty and other positions stay non-empty.
+type Sightlines struct {
+	// seen holds, for each direction and grid index, the grid index of the
+	// first non-empty position in that direction, or -1 if there is none.
+	seen   [len(deltas)][]Index
+	nRows  int
+	nCols  int
+	stride int
+}
+
+// Sightlines builds a table of the first non-empty position seen from each
+// position in each direction, where empty reports whether a value is empty.
+func (g *GridOf[T]) Sightlines(empty func(v T) bool) *Sightlines {
+	s := &Sightlines{nRows: g.nRows, nCols: g.nCols, stride: g.stride}
+	for _, d := range neighbors8 {
+		seen := make([]Index, len(g.cells))
+		dRow, dCol := d.delta()
+		// Visit the positions so that p.Step(d) is always visited before p, by
+		// going against d.
+		rows, cols := g.nRows, g.nCols
+		for r := range rows {
+			row := r
+			if dRow > 0 {
+				row = rows - 1 - r
+			}
+			for c := range cols {
+				col := c
+				if dCol > 0 {
+					col = cols - 1 - c
+				}
+				i := row*g.stride + col
+				q := Pos{Row: row + dRow, Col: col + dCol}
+				switch {
+				case !g.InBounds(q):
+					seen[i] = -1
+				case !empty(g.cells[g.Index(q)]):
+					seen[i] = g.Index(q)
+				default:
+					seen[i] = seen[g.Index(q)]
+				}
+			}
+		}
+		s.seen[d] = seen
+	}
+	return s
+}
+
+// Seen returns the first non-empty position beyond p in direction d, and
+// whether there was one. p must be in bounds, and d must not be None.
+func (s *Sightlines) Seen(p Pos, d Direction) (Pos, bool) {
+	if p.Row < 0 || p.Row >= s.nRows || p.Col < 0 || p.Col >= s.nCols {
+		panic(outOfBounds{p: p, nRows: s.nRows, nCols: s.nCols})
+	}
+	i := s.seen[d][p.Row*s.stride+p.Col]
+	if i == -1 {
+		return Pos{}, false
+	}
+	return Pos{Row: int(i) / s.stride, Col: int(i) % s.stride}, true
+}
+
+// All iterates over the first non-empty position seen from p in each of the
+// eight directions, in the same order as p.Neighbors8Seq(). Directions where
+// nothing is seen are skipped.
+func (s *Sightlines) All(p Pos) iter.Seq2[Direction, Pos] {
+	return func(yield func(Direction, Pos) bool) {
+		for _, d := range neighbors8 {
+			if q, ok := s.Seen(p, d); ok && !yield(d, q) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package asciigrid
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+func TestGrid_FirstAlong(t *testing.T) {
+	// The example from Advent of Code 2022 day 8. A tree is visible from
+	// outside the grid if there is no tree at least as tall as it in some
+	// direction, and its scenic score is the product of how far one can see
+	// in each direction.
+	g := newT(t, `
+30373
+25512
+65332
+33549
+35390
+`)
+	visible, bestScore := 0, 0
+	for p, h := range g.All() {
+		isVisible := false
+		score := 1
+		for _, d := range []Direction{Up, Down, Left, Right} {
+			dist := 0
+			if q, ok := g.FirstAlong(p, d, func(_ Pos, v byte) bool { return v >= h }); ok {
+				dist = max(abs(q.Row-p.Row), abs(q.Col-p.Col))
+			} else {
+				isVisible = true
+				for range g.Ray(p.Step(d), d) {
+					dist++
+				}
+			}
+			score *= dist
+		}
+		if isVisible {
+			visible++
+		}
+		bestScore = max(bestScore, score)
+	}
+	if got, want := visible, 21; got != want {
+		t.Errorf("visible trees = %d; want %d", got, want)
+	}
+	if got, want := bestScore, 8; got != want {
+		t.Errorf("best scenic score = %d; want %d", got, want)
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func TestSightlines(t *testing.T) {
+	// An example from Advent of Code 2020 day 11, where the empty seat in the
+	// middle can see eight occupied seats.
+	g := newT(t, `
+.......#.
+...#.....
+.#.......
+.........
+..#L....#
+....#....
+.........
+#........
+...#.....
+`)
+	s := g.Sightlines(func(b byte) bool { return b == '.' })
+	n := 0
+	for _, q := range s.All(Pos{Row: 4, Col: 3}) {
+		if g.Get(q) == '#' {
+			n++
+		}
+	}
+	if got, want := n, 8; got != want {
+		t.Errorf("occupied seats seen = %d; want %d", got, want)
+	}
+}
+
+func TestSightlines_BruteForce(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 200 {
+		rows, cols := 1+r.IntN(8), 1+r.IntN(8)
+		var sb strings.Builder
+		for row := range rows {
+			if row > 0 {
+				sb.WriteByte('\n')
+			}
+			for range cols {
+				sb.WriteByte(".#"[r.IntN(2)])
+			}
+		}
+		g := newT(t, sb.String())
+		s := g.Sightlines(func(b byte) bool { return b == '.' })
+		for p := range g.Bounds().All() {
+			for _, d := range neighbors8 {
+				want, wantOK := g.FirstAlong(p, d, func(_ Pos, b byte) bool { return b != '.' })
+				got, gotOK := s.Seen(p, d)
+				if got != want || gotOK != wantOK {
+					t.Fatalf("grid:\n%v\nSeen(%v, %v) = %v, %v; want %v, %v", g, p, d, got, gotOK, want, wantOK)
+				}
+			}
+		}
+	}
+}
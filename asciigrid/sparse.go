@@ -0,0 +1,155 @@
+package asciigrid
+
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// SparseGrid is an unbounded 2D grid, for puzzles where the world grows in
+// every direction and its size isn't known up front. Every position holds a
+// default value until something else is stored there, and only positions with
+// other values take up memory. Positions can be negative.
+//
+// SparseGrid uses the same Pos and Direction as the dense grids, so a solution
+// can switch between the two: use ToGrid to get a dense copy of the occupied
+// area, and SparseOf to go the other way.
+type SparseGrid[T comparable] struct {
+	cells map[Pos]T
+	def   T
+	// bounds is the bounding box of all positions in cells. It is only
+	// accurate if boundsOK is true; removing a position on the edge of the
+	// bounding box invalidates it, and it's recomputed when needed.
+	bounds   Rect
+	boundsOK bool
+}
+
+// NewSparse creates an empty sparse grid where every position holds def.
+func NewSparse[T comparable](def T) *SparseGrid[T] {
+	return &SparseGrid[T]{
+		cells:    make(map[Pos]T),
+		def:      def,
+		boundsOK: true,
+	}
+}
+
+// SparseOf creates a sparse grid holding the cells in g that are not def. The
+// positions in the sparse grid are the same as in g.
+func SparseOf[T comparable](g *GridOf[T], def T) *SparseGrid[T] {
+	s := NewSparse(def)
+	for p, v := range g.All() {
+		s.Set(p, v)
+	}
+	return s
+}
+
+// Default returns the value held by positions that haven't been set.
+func (s *SparseGrid[T]) Default() T {
+	return s.def
+}
+
+// Len returns the number of positions that hold something other than the
+// default value.
+func (s *SparseGrid[T]) Len() int {
+	return len(s.cells)
+}
+
+// Get returns the value at p, which is the default value if nothing else has
+// been stored there.
+func (s *SparseGrid[T]) Get(p Pos) T {
+	if v, ok := s.cells[p]; ok {
+		return v
+	}
+	return s.def
+}
+
+// Set stores v at p. Setting a position to the default value removes it from
+// the grid.
+func (s *SparseGrid[T]) Set(p Pos, v T) {
+	if v == s.def {
+		s.Delete(p)
+		return
+	}
+	if _, ok := s.cells[p]; !ok && s.boundsOK {
+		s.bounds = s.bounds.Union(RectOf(p))
+	}
+	s.cells[p] = v
+}
+
+// Delete resets p to the default value.
+func (s *SparseGrid[T]) Delete(p Pos) {
+	if _, ok := s.cells[p]; !ok {
+		return
+	}
+	delete(s.cells, p)
+	if !s.boundsOK {
+		return
+	}
+	if p.Row == s.bounds.Min.Row || p.Row == s.bounds.Max.Row-1 || p.Col == s.bounds.Min.Col || p.Col == s.bounds.Max.Col-1 {
+		s.boundsOK = false
+	}
+}
+
+// Bounds returns the smallest rectangle containing all positions that hold
+// something other than the default value. If there are no such positions,
+// Bounds returns the zero Rect.
+func (s *SparseGrid[T]) Bounds() Rect {
+	if !s.boundsOK {
+		s.bounds = Rect{}
+		for p := range s.cells {
+			s.bounds = s.bounds.Union(RectOf(p))
+		}
+		s.boundsOK = true
+	}
+	return s.bounds
+}
+
+// All iterates over the positions that hold something other than the default
+// value, in row-major order. All sorts the positions before iterating over
+// them, so use Cells if the order doesn't matter.
+func (s *SparseGrid[T]) All() iter.Seq2[Pos, T] {
+	return func(yield func(Pos, T) bool) {
+		ps := slices.SortedFunc(maps.Keys(s.cells), func(a, b Pos) int {
+			return cmp.Or(
+				cmp.Compare(a.Row, b.Row),
+				cmp.Compare(a.Col, b.Col),
+			)
+		})
+		for _, p := range ps {
+			if !yield(p, s.cells[p]) {
+				return
+			}
+		}
+	}
+}
+
+// Cells iterates over the positions that hold something other than the default
+// value, in an undefined order.
+func (s *SparseGrid[T]) Cells() iter.Seq2[Pos, T] {
+	return maps.All(s.cells)
+}
+
+// Clone returns a copy of s.
+func (s *SparseGrid[T]) Clone() *SparseGrid[T] {
+	s2 := *s
+	s2.cells = maps.Clone(s.cells)
+	return &s2
+}
+
+// ToGrid returns a dense grid covering s.Bounds(). The position p in s is at
+// p - offset in the returned grid, where offset is s.Bounds().Min.
+func (s *SparseGrid[T]) ToGrid() (g *GridOf[T], offset Pos) {
+	r := s.Bounds()
+	g = Make(r.NRows(), r.NCols(), s.def)
+	for p, v := range s.cells {
+		g.Set(Pos{Row: p.Row - r.Min.Row, Col: p.Col - r.Min.Col}, v)
+	}
+	return g, r.Min
+}
+
+// String renders the area within s.Bounds() in the same way as a dense grid.
+func (s *SparseGrid[T]) String() string {
+	g, _ := s.ToGrid()
+	return g.String()
+}
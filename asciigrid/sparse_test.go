@@ -0,0 +1,139 @@
+package asciigrid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSparseGrid_GetSet(t *testing.T) {
+	s := NewSparse(byte('.'))
+	if got, want := s.Get(Pos{Row: -100, Col: 100}), byte('.'); got != want {
+		t.Errorf("s.Get() on empty grid = %q; want %q", got, want)
+	}
+	s.Set(Pos{Row: -100, Col: 100}, '#')
+	if got, want := s.Get(Pos{Row: -100, Col: 100}), byte('#'); got != want {
+		t.Errorf("after s.Set(): s.Get() = %q; want %q", got, want)
+	}
+	if got, want := s.Len(), 1; got != want {
+		t.Errorf("after s.Set(): s.Len() = %v; want %v", got, want)
+	}
+	s.Set(Pos{Row: -100, Col: 100}, '.')
+	if got, want := s.Len(), 0; got != want {
+		t.Errorf("after setting default value: s.Len() = %v; want %v", got, want)
+	}
+}
+
+func TestSparseGrid_Bounds(t *testing.T) {
+	s := NewSparse(0)
+	if got, want := s.Bounds(), (Rect{}); got != want {
+		t.Errorf("empty grid: s.Bounds() = %v; want %v", got, want)
+	}
+	steps := []struct {
+		p    Pos
+		v    int
+		want Rect
+	}{
+		{p: Pos{Row: 0, Col: 0}, v: 1, want: Rect{Min: Pos{Row: 0, Col: 0}, Max: Pos{Row: 1, Col: 1}}},
+		{p: Pos{Row: -2, Col: 3}, v: 1, want: Rect{Min: Pos{Row: -2, Col: 0}, Max: Pos{Row: 1, Col: 4}}},
+		{p: Pos{Row: 5, Col: -1}, v: 1, want: Rect{Min: Pos{Row: -2, Col: -1}, Max: Pos{Row: 6, Col: 4}}},
+		{p: Pos{Row: 1, Col: 1}, v: 1, want: Rect{Min: Pos{Row: -2, Col: -1}, Max: Pos{Row: 6, Col: 4}}},
+		// Removing an interior position doesn't change the bounds.
+		{p: Pos{Row: 1, Col: 1}, v: 0, want: Rect{Min: Pos{Row: -2, Col: -1}, Max: Pos{Row: 6, Col: 4}}},
+		// Removing positions on the edge shrinks the bounds.
+		{p: Pos{Row: 5, Col: -1}, v: 0, want: Rect{Min: Pos{Row: -2, Col: 0}, Max: Pos{Row: 1, Col: 4}}},
+		{p: Pos{Row: -2, Col: 3}, v: 0, want: Rect{Min: Pos{Row: 0, Col: 0}, Max: Pos{Row: 1, Col: 1}}},
+		{p: Pos{Row: 0, Col: 0}, v: 0, want: Rect{}},
+	}
+	for _, step := range steps {
+		s.Set(step.p, step.v)
+		if got := s.Bounds(); got != step.want {
+			t.Errorf("after s.Set(%v, %v): s.Bounds() = %v; want %v", step.p, step.v, got, step.want)
+		}
+	}
+}
+
+func TestSparseGrid_All(t *testing.T) {
+	s := NewSparse(byte('.'))
+	ps := []Pos{
+		{Row: 3, Col: 0},
+		{Row: -1, Col: 5},
+		{Row: -1, Col: -5},
+		{Row: 0, Col: 0},
+		{Row: 3, Col: -3},
+	}
+	for _, p := range ps {
+		s.Set(p, '#')
+	}
+	var got []Pos
+	for p, b := range s.All() {
+		if b != '#' {
+			t.Errorf("s.All() yielded (%v, %q); want (%v, %q)", p, b, p, '#')
+		}
+		got = append(got, p)
+	}
+	want := []Pos{
+		{Row: -1, Col: -5},
+		{Row: -1, Col: 5},
+		{Row: 0, Col: 0},
+		{Row: 3, Col: -3},
+		{Row: 3, Col: 0},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("s.All() yielded unexpected positions (-want +got)\n%s", diff)
+	}
+}
+
+func TestSparseGrid_String(t *testing.T) {
+	s := NewSparse(byte('.'))
+	s.Set(Pos{Row: -1, Col: -1}, '#')
+	s.Set(Pos{Row: 1, Col: 2}, 'S')
+	want := strings.TrimSpace(`
+#...
+....
+...S
+`)
+	if got := s.String(); got != want {
+		t.Errorf("s.String() = %q; want %q", got, want)
+	}
+}
+
+func TestSparseGrid_Dense_RoundTrip(t *testing.T) {
+	g := newT(t, strings.TrimSpace(`
+.....
+..#..
+.#.#.
+.....
+`))
+	s := SparseOf(g, '.')
+	if got, want := s.Len(), 3; got != want {
+		t.Errorf("SparseOf(g).Len() = %v; want %v", got, want)
+	}
+	for p, b := range g.All() {
+		if got := s.Get(p); got != b {
+			t.Errorf("SparseOf(g).Get(%v) = %q; want %q", p, got, b)
+		}
+	}
+	dense, offset := s.ToGrid()
+	if got, want := offset, (Pos{Row: 1, Col: 1}); got != want {
+		t.Errorf("s.ToGrid() offset = %v; want %v", got, want)
+	}
+	if got, want := dense.String(), ".#.\n#.#"; got != want {
+		t.Errorf("s.ToGrid() grid = %q; want %q", got, want)
+	}
+}
+
+func TestSparseGrid_Clone(t *testing.T) {
+	s := NewSparse(0)
+	s.Set(Pos{Row: 1, Col: 1}, 1)
+	c := s.Clone()
+	c.Set(Pos{Row: 1, Col: 1}, 2)
+	c.Set(Pos{Row: 5, Col: 5}, 2)
+	if got, want := s.Get(Pos{Row: 1, Col: 1}), 1; got != want {
+		t.Errorf("after modifying clone: s.Get() = %v; want %v", got, want)
+	}
+	if got, want := s.Bounds(), RectOf(Pos{Row: 1, Col: 1}); got != want {
+		t.Errorf("after modifying clone: s.Bounds() = %v; want %v", got, want)
+	}
+}
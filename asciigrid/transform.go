@@ -0,0 +1,175 @@
+package asciigrid
+
+import (
+	"fmt"
+	"iter"
+)
+
+// makeZero allocates a grid with the given dimensions, where every cell holds
+// the zero value of T.
+func makeZero[T any](rows, cols int) *GridOf[T] {
+	return &GridOf[T]{
+		cells:  make([]T, rows*cols),
+		stride: cols,
+		nRows:  rows,
+		nCols:  cols,
+	}
+}
+
+// forEachRow calls f for each row in g, with the row number and the cells in
+// that row.
+func (g *GridOf[T]) forEachRow(f func(row int, cells []T)) {
+	for row := 0; row < g.nRows; row++ {
+		start := row * g.stride
+		f(row, g.cells[start:start+g.nCols])
+	}
+}
+
+// Transpose returns a new grid where rows and columns have swapped places, i.e.
+// the value at (row, col) in g is at (col, row) in the returned grid:
+//
+//	abc      ad
+//	def  =>  be
+//	         cf
+func (g *GridOf[T]) Transpose() *GridOf[T] {
+	g2 := makeZero[T](g.nCols, g.nRows)
+	g.forEachRow(func(row int, cells []T) {
+		for col, v := range cells {
+			g2.cells[col*g2.stride+row] = v
+		}
+	})
+	return g2
+}
+
+// Rotate90 returns a new grid that is g rotated 90 degrees clockwise:
+//
+//	abc      da
+//	def  =>  eb
+//	         fc
+func (g *GridOf[T]) Rotate90() *GridOf[T] {
+	g2 := makeZero[T](g.nCols, g.nRows)
+	g.forEachRow(func(row int, cells []T) {
+		for col, v := range cells {
+			g2.cells[col*g2.stride+g.nRows-1-row] = v
+		}
+	})
+	return g2
+}
+
+// Rotate180 returns a new grid that is g rotated 180 degrees:
+//
+//	abc  =>  fed
+//	def      cba
+func (g *GridOf[T]) Rotate180() *GridOf[T] {
+	g2 := makeZero[T](g.nRows, g.nCols)
+	g.forEachRow(func(row int, cells []T) {
+		for col, v := range cells {
+			g2.cells[(g.nRows-1-row)*g2.stride+g.nCols-1-col] = v
+		}
+	})
+	return g2
+}
+
+// Rotate270 returns a new grid that is g rotated 270 degrees clockwise, which is
+// the same as 90 degrees counter-clockwise:
+//
+//	abc      cf
+//	def  =>  be
+//	         ad
+func (g *GridOf[T]) Rotate270() *GridOf[T] {
+	g2 := makeZero[T](g.nCols, g.nRows)
+	g.forEachRow(func(row int, cells []T) {
+		for col, v := range cells {
+			g2.cells[(g.nCols-1-col)*g2.stride+row] = v
+		}
+	})
+	return g2
+}
+
+// FlipH returns a new grid that is g mirrored horizontally, i.e. the order of
+// the columns is reversed:
+//
+//	abc  =>  cba
+//	def      fed
+func (g *GridOf[T]) FlipH() *GridOf[T] {
+	g2 := makeZero[T](g.nRows, g.nCols)
+	g.forEachRow(func(row int, cells []T) {
+		for col, v := range cells {
+			g2.cells[row*g2.stride+g.nCols-1-col] = v
+		}
+	})
+	return g2
+}
+
+// FlipV returns a new grid that is g mirrored vertically, i.e. the order of the
+// rows is reversed:
+//
+//	abc  =>  def
+//	def      abc
+func (g *GridOf[T]) FlipV() *GridOf[T] {
+	g2 := makeZero[T](g.nRows, g.nCols)
+	g.forEachRow(func(row int, cells []T) {
+		for col, v := range cells {
+			g2.cells[(g.nRows-1-row)*g2.stride+col] = v
+		}
+	})
+	return g2
+}
+
+// Orientations iterates over the eight ways g can be rotated and flipped (the
+// dihedral group of the square). The first grid yielded is a clone of g,
+// followed by its rotations by 90, 180 and 270 degrees clockwise, and then the
+// same four for g flipped horizontally. If g is symmetrical some of the yielded
+// grids are equal.
+func (g *GridOf[T]) Orientations() iter.Seq[*GridOf[T]] {
+	return func(yield func(*GridOf[T]) bool) {
+		for _, g2 := range []*GridOf[T]{g, g.FlipH()} {
+			if !yield(g2.Clone()) ||
+				!yield(g2.Rotate90()) ||
+				!yield(g2.Rotate180()) ||
+				!yield(g2.Rotate270()) {
+				return
+			}
+		}
+	}
+}
+
+// SubGrid returns a new grid holding a copy of the cells of g within r. The
+// position r.Min in g is at (0, 0) in the returned grid. SubGrid panics if r is
+// not within the bounds of g.
+func (g *GridOf[T]) SubGrid(r Rect) *GridOf[T] {
+	if !g.Bounds().ContainsRect(r) {
+		panic(fmt.Errorf("asciigrid: SubGrid(%v) is out of bounds in a grid with bounds %v", r, g.Bounds()))
+	}
+	g2 := makeZero[T](r.NRows(), r.NCols())
+	for p := range g2.Bounds().All() {
+		g2.Set(p, g.Get(Pos{Row: r.Min.Row + p.Row, Col: r.Min.Col + p.Col}))
+	}
+	return g2
+}
+
+// Paste copies all cells of src into g, such that (0, 0) in src ends up at the
+// position at in g. Paste panics if src doesn't fit within g at that position.
+func (g *GridOf[T]) Paste(src *GridOf[T], at Pos) {
+	r := Rect{Min: at, Max: Pos{Row: at.Row + src.nRows, Col: at.Col + src.nCols}}
+	if !g.Bounds().ContainsRect(r) {
+		panic(fmt.Errorf("asciigrid: Paste() of a %dx%d grid at %v is out of bounds in a grid with bounds %v", src.nRows, src.nCols, at, g.Bounds()))
+	}
+	for p, v := range src.All() {
+		g.Set(Pos{Row: at.Row + p.Row, Col: at.Col + p.Col}, v)
+	}
+}
+
+// Equal reports whether a and b have the same dimensions and hold equal values
+// in all positions.
+func Equal[T comparable](a, b *GridOf[T]) bool {
+	if a.nRows != b.nRows || a.nCols != b.nCols {
+		return false
+	}
+	for p, v := range a.All() {
+		if b.Get(p) != v {
+			return false
+		}
+	}
+	return true
+}
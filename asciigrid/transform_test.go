@@ -0,0 +1,222 @@
+package asciigrid
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+// randomGrids returns a variety of grids with random contents, including
+// non-square and degenerate ones.
+func randomGrids(t *testing.T) []*Grid {
+	t.Helper()
+	r := rand.New(rand.NewPCG(1, 2))
+	var gs []*Grid
+	for _, dims := range [][2]int{{1, 1}, {1, 5}, {5, 1}, {2, 3}, {4, 4}, {7, 3}, {10, 12}} {
+		var sb strings.Builder
+		for row := range dims[0] {
+			if row > 0 {
+				sb.WriteByte('\n')
+			}
+			for range dims[1] {
+				sb.WriteByte(byte('a' + r.IntN(26)))
+			}
+		}
+		gs = append(gs, newT(t, sb.String()))
+	}
+	return gs
+}
+
+func TestGrid_Transforms(t *testing.T) {
+	g := newT(t, "abc\ndef")
+	for _, tt := range []struct {
+		name string
+		f    func(*Grid) *Grid
+		want string
+	}{
+		{name: "Transpose", f: (*Grid).Transpose, want: "ad\nbe\ncf"},
+		{name: "Rotate90", f: (*Grid).Rotate90, want: "da\neb\nfc"},
+		{name: "Rotate180", f: (*Grid).Rotate180, want: "fed\ncba"},
+		{name: "Rotate270", f: (*Grid).Rotate270, want: "cf\nbe\nad"},
+		{name: "FlipH", f: (*Grid).FlipH, want: "cba\nfed"},
+		{name: "FlipV", f: (*Grid).FlipV, want: "def\nabc"},
+	} {
+		if got := tt.f(g).String(); got != tt.want {
+			t.Errorf("%s(%q) = %q; want %q", tt.name, g, got, tt.want)
+		}
+	}
+	if got, want := g.String(), "abc\ndef"; got != want {
+		t.Errorf("after transforms: g.String() = %q; want %q", got, want)
+	}
+}
+
+func TestGrid_Transforms_Properties(t *testing.T) {
+	for _, g := range randomGrids(t) {
+		for _, tt := range []struct {
+			name string
+			got  *Grid
+			want *Grid
+		}{
+			{name: "Rotate90 four times", got: g.Rotate90().Rotate90().Rotate90().Rotate90(), want: g},
+			{name: "Rotate90 twice", got: g.Rotate90().Rotate90(), want: g.Rotate180()},
+			{name: "Rotate90 three times", got: g.Rotate90().Rotate90().Rotate90(), want: g.Rotate270()},
+			{name: "Rotate90 then Rotate270", got: g.Rotate90().Rotate270(), want: g},
+			{name: "Transpose twice", got: g.Transpose().Transpose(), want: g},
+			{name: "FlipH twice", got: g.FlipH().FlipH(), want: g},
+			{name: "FlipV twice", got: g.FlipV().FlipV(), want: g},
+			{name: "FlipH then FlipV", got: g.FlipH().FlipV(), want: g.Rotate180()},
+			{name: "Transpose then FlipH", got: g.Transpose().FlipH(), want: g.Rotate90()},
+			{name: "Transpose then FlipV", got: g.Transpose().FlipV(), want: g.Rotate270()},
+		} {
+			if !Equal(tt.got, tt.want) {
+				t.Errorf("%s: got\n%v\nwant\n%v", tt.name, tt.got, tt.want)
+			}
+		}
+	}
+}
+
+func TestGrid_Orientations(t *testing.T) {
+	for _, g := range randomGrids(t) {
+		var os []*Grid
+		for o := range g.Orientations() {
+			os = append(os, o)
+		}
+		if got, want := len(os), 8; got != want {
+			t.Fatalf("Orientations() yielded %d grids; want %d", got, want)
+		}
+		// All the named transforms must be among the orientations.
+		for _, want := range []*Grid{g, g.Rotate90(), g.Rotate180(), g.Rotate270(), g.FlipH(), g.FlipV(), g.Transpose(), g.Transpose().Rotate180()} {
+			found := false
+			for _, o := range os {
+				if Equal(o, want) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("Orientations() of\n%v\ndoes not include\n%v", g, want)
+			}
+		}
+		// The orientations form a group: transforming any of them yields
+		// another one of them.
+		for _, o := range os {
+			r := o.Rotate90()
+			found := false
+			for _, o2 := range os {
+				if Equal(r, o2) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("Rotate90() of orientation\n%v\nis not among the orientations", o)
+			}
+		}
+	}
+}
+
+func TestGrid_Orientations_Distinct(t *testing.T) {
+	// A grid without any symmetry has eight distinct orientations.
+	g := newT(t, "ab\ncd")
+	var os []*Grid
+	for o := range g.Orientations() {
+		for _, prev := range os {
+			if Equal(o, prev) {
+				t.Errorf("Orientations() yielded\n%v\nmore than once", o)
+			}
+		}
+		os = append(os, o)
+	}
+}
+
+func TestGrid_Clone_Independent(t *testing.T) {
+	for _, g := range randomGrids(t) {
+		c := g.Clone()
+		if !Equal(g, c) {
+			t.Errorf("Clone() of\n%v\n= %v", g, c)
+		}
+		c.Set(Pos{}, '#')
+		if g.Get(Pos{}) == '#' {
+			t.Errorf("modifying clone modified original:\n%v", g)
+		}
+	}
+}
+
+func TestGrid_SubGrid(t *testing.T) {
+	g := newT(t, "abcd\nefgh\nijkl")
+	for _, tt := range []struct {
+		r    Rect
+		want string
+	}{
+		{r: g.Bounds(), want: g.String()},
+		{r: Rect{Min: Pos{Row: 1, Col: 1}, Max: Pos{Row: 3, Col: 3}}, want: "fg\njk"},
+		{r: Rect{Min: Pos{Row: 0, Col: 3}, Max: Pos{Row: 3, Col: 4}}, want: "d\nh\nl"},
+		{r: Rect{Min: Pos{Row: 1, Col: 1}, Max: Pos{Row: 1, Col: 1}}, want: ""},
+	} {
+		if got := g.SubGrid(tt.r).String(); got != tt.want {
+			t.Errorf("g.SubGrid(%v) = %q; want %q", tt.r, got, tt.want)
+		}
+	}
+}
+
+func TestGrid_SubGrid_OutOfBounds(t *testing.T) {
+	g := newT(t, "abcd\nefgh\nijkl")
+	for _, r := range []Rect{
+		{Min: Pos{Row: -1, Col: 0}, Max: Pos{Row: 1, Col: 1}},
+		{Min: Pos{Row: 0, Col: 0}, Max: Pos{Row: 4, Col: 1}},
+		{Min: Pos{Row: 0, Col: 2}, Max: Pos{Row: 1, Col: 5}},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("g.SubGrid(%v) didn't panic", r)
+				}
+			}()
+			g.SubGrid(r)
+		}()
+	}
+}
+
+func TestGrid_Paste(t *testing.T) {
+	for _, g := range randomGrids(t) {
+		// Pasting every sub-grid back where it came from leaves the grid
+		// unchanged.
+		c := Make(g.NRows(), g.NCols(), byte('.'))
+		for row := 0; row < g.NRows(); row += 2 {
+			for col := 0; col < g.NCols(); col += 3 {
+				r := Rect{Min: Pos{Row: row, Col: col}, Max: Pos{Row: row + 2, Col: col + 3}}.Intersect(g.Bounds())
+				c.Paste(g.SubGrid(r), r.Min)
+			}
+		}
+		if !Equal(g, c) {
+			t.Errorf("pasting sub-grids of\n%v\nresulted in\n%v", g, c)
+		}
+	}
+
+	g := newT(t, "....\n....\n....")
+	g.Paste(newT(t, "ab\ncd"), Pos{Row: 1, Col: 2})
+	if got, want := g.String(), "....\n..ab\n..cd"; got != want {
+		t.Errorf("after Paste(): g.String() = %q; want %q", got, want)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		want bool
+	}{
+		{a: "", b: "", want: true},
+		{a: "ab\ncd", b: "ab\ncd", want: true},
+		{a: "ab\ncd", b: "ab\ncx", want: false},
+		{a: "ab\ncd", b: "abcd", want: false},
+		{a: "ab\ncd", b: "ac\nbd", want: false},
+	} {
+		if got := Equal(newT(t, tt.a), newT(t, tt.b)); got != tt.want {
+			t.Errorf("Equal(%q, %q) = %v; want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+	// Grids with different layouts can still be equal.
+	if got, want := Equal(newT(t, "..\n.."), Make(2, 2, byte('.'))), true; got != want {
+		t.Errorf("Equal(New(\"..\\n..\"), Make(2, 2, '.')) = %v; want %v", got, want)
+	}
+}
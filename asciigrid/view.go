@@ -0,0 +1,226 @@
+package asciigrid
+
+import "iter"
+
+// View is the read-only interface shared by grids and the different ways of
+// looking at them, such as Torus and Tiled. Code that only needs to look at
+// cells and walk between them can be written against View and work on all of
+// them.
+type View[T any] interface {
+	// Get returns the value at p.
+	Get(p Pos) T
+	// InBounds reports whether p can be passed to Get.
+	InBounds(p Pos) bool
+	// Neighbors4 iterates over the direct neighbors of p that are in bounds.
+	Neighbors4(p Pos) iter.Seq2[Direction, Pos]
+	// Neighbors8 iterates over the direct and diagonal neighbors of p that
+	// are in bounds.
+	Neighbors8(p Pos) iter.Seq2[Direction, Pos]
+}
+
+var (
+	_ View[byte] = (*GridOf[byte])(nil)
+	_ View[byte] = Torus[byte]{}
+	_ View[byte] = Tiled[byte]{}
+)
+
+// mod returns x modulo n, which unlike x % n is never negative.
+func mod(x, n int) int {
+	// Most positions are at most one grid away from the grid itself, e.g. after
+	// taking a step, so avoid the division for those.
+	switch {
+	case 0 <= x && x < n:
+		return x
+	case n <= x && x < 2*n:
+		return x - n
+	case -n <= x && x < 0:
+		return x + n
+	}
+	x %= n
+	if x < 0 {
+		x += n
+	}
+	return x
+}
+
+// Wrap returns the position that p wraps around to in a grid with nRows rows
+// and nCols columns, as if the edges of the grid were connected like in a Torus.
+// It's useful for positions that wrap around a grid that isn't stored anywhere.
+func Wrap(p Pos, nRows, nCols int) Pos {
+	return Pos{
+		Row: mod(p.Row, nRows),
+		Col: mod(p.Col, nCols),
+	}
+}
+
+// Torus is a view of a grid where the edges wrap around: stepping off the right
+// edge leads to the left edge of the same row, stepping off the bottom leads to
+// the top of the same column, and so on. Every position is in bounds, and is
+// wrapped onto the grid before it's used.
+//
+// A Torus is a view, so changes to the underlying grid are visible through it
+// and vice versa.
+type Torus[T any] struct {
+	g *GridOf[T]
+}
+
+// Torus returns a view of g where positions wrap around the edges.
+func (g *GridOf[T]) Torus() Torus[T] {
+	return Torus[T]{g: g}
+}
+
+// Grid returns the underlying grid.
+func (t Torus[T]) Grid() *GridOf[T] {
+	return t.g
+}
+
+// Wrap returns the position in the underlying grid that p corresponds to.
+func (t Torus[T]) Wrap(p Pos) Pos {
+	return Wrap(p, t.g.nRows, t.g.nCols)
+}
+
+// Get returns the value at p, after wrapping it.
+func (t Torus[T]) Get(p Pos) T {
+	return t.g.Get(t.Wrap(p))
+}
+
+// Set stores v at p, after wrapping it.
+func (t Torus[T]) Set(p Pos, v T) {
+	t.g.Set(t.Wrap(p), v)
+}
+
+// InBounds always returns true, because every position wraps onto the grid.
+func (t Torus[T]) InBounds(p Pos) bool {
+	return true
+}
+
+// Step returns the wrapped position a single step from p in the given
+// direction.
+func (t Torus[T]) Step(p Pos, d Direction) Pos {
+	return t.Wrap(p.Step(d))
+}
+
+// StepN is like Step but takes n steps in the given direction.
+func (t Torus[T]) StepN(p Pos, d Direction, n int) Pos {
+	return t.Wrap(p.StepN(d, n))
+}
+
+// Neighbors4 iterates over the wrapped direct neighbors of p, in the same
+// order as p.Neighbors4Seq().
+func (t Torus[T]) Neighbors4(p Pos) iter.Seq2[Direction, Pos] {
+	return func(yield func(Direction, Pos) bool) {
+		for _, d := range neighbors4 {
+			if !yield(d, t.Step(p, d)) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors8 iterates over the wrapped direct and diagonal neighbors of p, in
+// the same order as p.Neighbors8Seq().
+func (t Torus[T]) Neighbors8(p Pos) iter.Seq2[Direction, Pos] {
+	return func(yield func(Direction, Pos) bool) {
+		for _, d := range neighbors8 {
+			if !yield(d, t.Step(p, d)) {
+				return
+			}
+		}
+	}
+}
+
+// Tiled is a view of a grid repeated infinitely in every direction, like tiles
+// on a floor. The tile at (0, 0) covers the same positions as the underlying
+// grid; the tile at (0, 1) is immediately to the right of it, the tile at
+// (-1, 0) immediately above it, and so on. Every position is in bounds.
+//
+// Unlike Torus, positions are not wrapped: taking a step off the edge of one
+// tile leads onto the next one, and the position reflects that. Use Locate to
+// find out which tile a position is in.
+//
+// A Tiled is a view, so changes to the underlying grid are visible through it
+// and vice versa. Since all tiles share the underlying grid, a change is
+// visible in every tile.
+type Tiled[T any] struct {
+	g *GridOf[T]
+}
+
+// Tiled returns a view of g repeated infinitely in every direction.
+func (g *GridOf[T]) Tiled() Tiled[T] {
+	return Tiled[T]{g: g}
+}
+
+// Grid returns the underlying grid.
+func (t Tiled[T]) Grid() *GridOf[T] {
+	return t.g
+}
+
+// Locate returns the coordinate of the tile that p is in, and the position
+// within that tile (and therefore within the underlying grid). For example, in
+// a grid with 10 rows and 10 columns:
+//
+//	Locate(Pos{Row: 3, Col: 4})   = Pos{Row: 0, Col: 0}, Pos{Row: 3, Col: 4}
+//	Locate(Pos{Row: 13, Col: 4})  = Pos{Row: 1, Col: 0}, Pos{Row: 3, Col: 4}
+//	Locate(Pos{Row: -1, Col: -1}) = Pos{Row: -1, Col: -1}, Pos{Row: 9, Col: 9}
+func (t Tiled[T]) Locate(p Pos) (tile, local Pos) {
+	local = Pos{
+		Row: mod(p.Row, t.g.nRows),
+		Col: mod(p.Col, t.g.nCols),
+	}
+	tile = Pos{
+		Row: (p.Row - local.Row) / t.g.nRows,
+		Col: (p.Col - local.Col) / t.g.nCols,
+	}
+	return tile, local
+}
+
+// Global is the inverse of Locate: it returns the position of local within the
+// given tile.
+func (t Tiled[T]) Global(tile, local Pos) Pos {
+	return Pos{
+		Row: tile.Row*t.g.nRows + local.Row,
+		Col: tile.Col*t.g.nCols + local.Col,
+	}
+}
+
+// Get returns the value at p.
+func (t Tiled[T]) Get(p Pos) T {
+	_, local := t.Locate(p)
+	return t.g.Get(local)
+}
+
+// Set stores v at p. Since all tiles share the underlying grid, this changes
+// the corresponding position in every tile.
+func (t Tiled[T]) Set(p Pos, v T) {
+	_, local := t.Locate(p)
+	t.g.Set(local, v)
+}
+
+// InBounds always returns true, because the tiles cover every position.
+func (t Tiled[T]) InBounds(p Pos) bool {
+	return true
+}
+
+// Step returns the position a single step from p in the given direction. It is
+// the same as p.Step(d), and exists so that Tiled has the same methods as
+// Torus.
+func (t Tiled[T]) Step(p Pos, d Direction) Pos {
+	return p.Step(d)
+}
+
+// StepN is like Step but takes n steps in the given direction.
+func (t Tiled[T]) StepN(p Pos, d Direction, n int) Pos {
+	return p.StepN(d, n)
+}
+
+// Neighbors4 iterates over the direct neighbors of p. It is the same as
+// p.Neighbors4Seq().
+func (t Tiled[T]) Neighbors4(p Pos) iter.Seq2[Direction, Pos] {
+	return p.Neighbors4Seq()
+}
+
+// Neighbors8 iterates over the direct and diagonal neighbors of p. It is the
+// same as p.Neighbors8Seq().
+func (t Tiled[T]) Neighbors8(p Pos) iter.Seq2[Direction, Pos] {
+	return p.Neighbors8Seq()
+}
@@ -0,0 +1,175 @@
+package asciigrid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTorus_Wrap(t *testing.T) {
+	g := Make(3, 5, 0)
+	torus := g.Torus()
+	for _, tt := range []struct {
+		p    Pos
+		want Pos
+	}{
+		{p: Pos{Row: 0, Col: 0}, want: Pos{Row: 0, Col: 0}},
+		{p: Pos{Row: 2, Col: 4}, want: Pos{Row: 2, Col: 4}},
+		{p: Pos{Row: 3, Col: 5}, want: Pos{Row: 0, Col: 0}},
+		{p: Pos{Row: -1, Col: -1}, want: Pos{Row: 2, Col: 4}},
+		{p: Pos{Row: -7, Col: 12}, want: Pos{Row: 2, Col: 2}},
+		{p: Pos{Row: 300, Col: -500}, want: Pos{Row: 0, Col: 0}},
+	} {
+		if got := torus.Wrap(tt.p); got != tt.want {
+			t.Errorf("torus.Wrap(%v) = %v; want %v", tt.p, got, tt.want)
+		}
+		if got := Wrap(tt.p, 3, 5); got != tt.want {
+			t.Errorf("Wrap(%v, 3, 5) = %v; want %v", tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestTorus_GetSet(t *testing.T) {
+	g := newT(t, strings.TrimSpace(`
+abc
+def
+`))
+	torus := g.Torus()
+	if got, want := torus.Get(Pos{Row: -1, Col: -1}), byte('f'); got != want {
+		t.Errorf("torus.Get({-1, -1}) = %q; want %q", got, want)
+	}
+	torus.Set(Pos{Row: 2, Col: 3}, '#')
+	if got, want := g.String(), "#bc\ndef"; got != want {
+		t.Errorf("after torus.Set({2, 3}): g.String() = %q; want %q", got, want)
+	}
+}
+
+func TestTorus_Neighbors(t *testing.T) {
+	torus := Make(3, 3, 0).Torus()
+	p := Pos{Row: 0, Col: 0}
+	var got []Pos
+	for d, n := range torus.Neighbors4(p) {
+		if want := torus.Step(p, d); n != want {
+			t.Errorf("torus.Neighbors4(%v) yielded (%v, %v); want (%v, %v)", p, d, n, d, want)
+		}
+		got = append(got, n)
+	}
+	want := []Pos{
+		{Row: 2, Col: 0}, // Up
+		{Row: 0, Col: 1}, // Right
+		{Row: 1, Col: 0}, // Down
+		{Row: 0, Col: 2}, // Left
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("torus.Neighbors4(%v) yielded unexpected positions (-want +got)\n%s", p, diff)
+	}
+	n := 0
+	for range torus.Neighbors8(p) {
+		n++
+	}
+	if got, want := n, 8; got != want {
+		t.Errorf("torus.Neighbors8(%v) yielded %d positions; want %d", p, got, want)
+	}
+}
+
+func TestTiled_Locate(t *testing.T) {
+	tiled := Make(10, 10, 0).Tiled()
+	for _, tt := range []struct {
+		p         Pos
+		wantTile  Pos
+		wantLocal Pos
+	}{
+		{p: Pos{Row: 3, Col: 4}, wantTile: Pos{Row: 0, Col: 0}, wantLocal: Pos{Row: 3, Col: 4}},
+		{p: Pos{Row: 13, Col: 4}, wantTile: Pos{Row: 1, Col: 0}, wantLocal: Pos{Row: 3, Col: 4}},
+		{p: Pos{Row: -1, Col: -1}, wantTile: Pos{Row: -1, Col: -1}, wantLocal: Pos{Row: 9, Col: 9}},
+		{p: Pos{Row: -10, Col: 10}, wantTile: Pos{Row: -1, Col: 1}, wantLocal: Pos{Row: 0, Col: 0}},
+		{p: Pos{Row: -11, Col: 29}, wantTile: Pos{Row: -2, Col: 2}, wantLocal: Pos{Row: 9, Col: 9}},
+	} {
+		gotTile, gotLocal := tiled.Locate(tt.p)
+		if gotTile != tt.wantTile || gotLocal != tt.wantLocal {
+			t.Errorf("tiled.Locate(%v) = %v, %v; want %v, %v", tt.p, gotTile, gotLocal, tt.wantTile, tt.wantLocal)
+		}
+		if got := tiled.Global(gotTile, gotLocal); got != tt.p {
+			t.Errorf("tiled.Global(tiled.Locate(%v)) = %v; want %v", tt.p, got, tt.p)
+		}
+	}
+}
+
+func TestTiled_Get(t *testing.T) {
+	g := newT(t, strings.TrimSpace(`
+ab
+cd
+`))
+	tiled := g.Tiled()
+	var sb strings.Builder
+	for row := -2; row < 3; row++ {
+		for col := -2; col < 3; col++ {
+			sb.WriteByte(tiled.Get(Pos{Row: row, Col: col}))
+		}
+		sb.WriteByte('\n')
+	}
+	want := `ababa
+cdcdc
+ababa
+cdcdc
+ababa
+`
+	if got := sb.String(); got != want {
+		t.Errorf("tiled.Get() over [-2, 3) x [-2, 3) =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// countReachable counts the positions reachable from start in exactly steps
+// steps, where '#' is impassable. It is written against View, to check that
+// it works the same for all views.
+func countReachable(v View[byte], start Pos, steps int) int {
+	frontier := map[Pos]bool{start: true}
+	for range steps {
+		next := make(map[Pos]bool)
+		for p := range frontier {
+			for _, n := range v.Neighbors4(p) {
+				if v.Get(n) != '#' {
+					next[n] = true
+				}
+			}
+		}
+		frontier = next
+	}
+	return len(frontier)
+}
+
+func TestView(t *testing.T) {
+	// This is the example from 2023 day 21.
+	g := newT(t, strings.TrimSpace(`
+...........
+.....###.#.
+.###.##..#.
+..#.#...#..
+....#.#....
+.##..S####.
+.##..#...#.
+.......##..
+.##.#.####.
+.##..##.##.
+...........
+`))
+	start := Pos{Row: 5, Col: 5}
+	for _, tt := range []struct {
+		steps int
+		want  int
+	}{
+		{steps: 6, want: 16},
+		{steps: 10, want: 50},
+		{steps: 50, want: 1594},
+		{steps: 100, want: 6536},
+	} {
+		if got := countReachable(g.Tiled(), start, tt.steps); got != tt.want {
+			t.Errorf("countReachable(g.Tiled(), %v, %v) = %v; want %v", start, tt.steps, got, tt.want)
+		}
+	}
+	// Within the bounds of the grid, all views agree.
+	if got, want := countReachable(g, start, 6), countReachable(g.Tiled(), start, 6); got != want {
+		t.Errorf("countReachable(g, %v, 6) = %v; want %v", start, got, want)
+	}
+}
@@ -0,0 +1,105 @@
+package geometry
+
+import (
+	"cmp"
+	"slices"
+)
+
+// CompareAngle compares the directions of a and b by the angle they make with
+// the direction from, going in the direction of increasing angle. Vectors
+// pointing in the direction of from have angle 0 and come first, and the angle
+// increases up to a full turn. The comparison is exact, without floating point.
+// Vectors pointing in the same direction compare equal regardless of their
+// length. The zero vector compares before all others, and so does a zero from.
+//
+// With Y pointing up, the angle increases counterclockwise. With Y pointing
+// down, as with rows in a grid, it increases clockwise.
+func CompareAngle(from, a, b Pos2) int {
+	if from == (Pos2{}) {
+		from = Pos2{X: 1, Y: 0}
+	}
+	// half returns 0 for vectors less than half a turn from from, 1 for the
+	// others, and -1 for the zero vector.
+	half := func(v Pos2) int {
+		if v == (Pos2{}) {
+			return -1
+		}
+		c := from.Cross(v)
+		if c > 0 || c == 0 && from.Dot(v) > 0 {
+			return 0
+		}
+		return 1
+	}
+	if c := cmp.Compare(half(a), half(b)); c != 0 {
+		return c
+	}
+	// a and b are in the same half, so the one which is less than half a turn
+	// before the other comes first.
+	return -cmp.Compare(a.Cross(b), 0)
+}
+
+// SortByAngle sorts the points ps by the angle of their direction from center,
+// as compared by CompareAngle starting at direction from. Points in the same
+// direction are sorted by their distance from center, closest first.
+func SortByAngle(ps []Pos2, center, from Pos2) {
+	slices.SortStableFunc(ps, func(a, b Pos2) int {
+		da, db := a.Sub(center), b.Sub(center)
+		if c := CompareAngle(from, da, db); c != 0 {
+			return c
+		}
+		return cmp.Compare(da.L1Norm(), db.L1Norm())
+	})
+}
+
+// Visible returns the points in ps that are visible from center, i.e. the ones
+// that don't have any other point in ps directly between them and center. It
+// keeps the order of ps. A point at center is never visible.
+func Visible(center Pos2, ps []Pos2) []Pos2 {
+	closest := make(map[Pos2]Pos2)
+	for _, p := range ps {
+		d := p.Sub(center)
+		if d == (Pos2{}) {
+			continue
+		}
+		dir := d.Reduce()
+		if q, ok := closest[dir]; !ok || d.L1Norm() < q.Sub(center).L1Norm() {
+			closest[dir] = p
+		}
+	}
+	var visible []Pos2
+	for _, p := range ps {
+		if d := p.Sub(center); d != (Pos2{}) && closest[d.Reduce()] == p {
+			visible = append(visible, p)
+		}
+	}
+	return visible
+}
+
+// Sweep returns the order in which a beam rotating around center, starting in
+// direction from and going in the direction of increasing angle, hits the
+// points in ps. The beam only hits the closest point in each direction per
+// rotation, so points hidden behind others are hit in later rotations. Points
+// at center are never hit.
+func Sweep(center Pos2, ps []Pos2, from Pos2) []Pos2 {
+	sorted := slices.DeleteFunc(slices.Clone(ps), func(p Pos2) bool { return p == center })
+	SortByAngle(sorted, center, from)
+	// Split the sorted points into runs of the same direction, each sorted by
+	// distance, and take one point from each run per rotation.
+	var runs [][]Pos2
+	for i, p := range sorted {
+		if i == 0 || p.Sub(center).Reduce() != sorted[i-1].Sub(center).Reduce() {
+			runs = append(runs, nil)
+		}
+		runs[len(runs)-1] = append(runs[len(runs)-1], p)
+	}
+	order := make([]Pos2, 0, len(sorted))
+	for len(order) < len(sorted) {
+		for i, run := range runs {
+			if len(run) > 0 {
+				order = append(order, run[0])
+				runs[i] = run[1:]
+			}
+		}
+	}
+	return order
+}
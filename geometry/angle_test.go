@@ -0,0 +1,178 @@
+package geometry
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPos2_Reduce(t *testing.T) {
+	for _, tt := range []struct {
+		p, want Pos2
+	}{
+		{p: Pos2{0, 0}, want: Pos2{0, 0}},
+		{p: Pos2{4, 0}, want: Pos2{1, 0}},
+		{p: Pos2{0, -3}, want: Pos2{0, -1}},
+		{p: Pos2{6, -4}, want: Pos2{3, -2}},
+		{p: Pos2{-5, -10}, want: Pos2{-1, -2}},
+		{p: Pos2{7, 3}, want: Pos2{7, 3}},
+	} {
+		if got := tt.p.Reduce(); got != tt.want {
+			t.Errorf("%v.Reduce() = %v; want %v", tt.p, got, tt.want)
+		}
+	}
+}
+
+// bruteAngle returns the angle of v relative to from in [0, 2π) using floating
+// point.
+func bruteAngle(from, v Pos2) float64 {
+	a := math.Atan2(float64(v.Y), float64(v.X)) - math.Atan2(float64(from.Y), float64(from.X))
+	if a < 0 {
+		a += 2 * math.Pi
+	}
+	return a
+}
+
+// bruteCompareAngle is a floating point version of CompareAngle.
+func bruteCompareAngle(from, a, b Pos2) int {
+	switch {
+	case a == Pos2{} && b == Pos2{}:
+		return 0
+	case a == Pos2{}:
+		return -1
+	case b == Pos2{}:
+		return +1
+	case a.Reduce() == b.Reduce():
+		return 0
+	}
+	fa, fb := bruteAngle(from, a), bruteAngle(from, b)
+	switch {
+	case fa < fb:
+		return -1
+	case fa > fb:
+		return +1
+	}
+	return 0
+}
+
+func randomPos2(r *rand.Rand, n int) Pos2 {
+	return Pos2{X: r.IntN(2*n+1) - n, Y: r.IntN(2*n+1) - n}
+}
+
+func TestCompareAngle_BruteForce(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 100000 {
+		from := randomPos2(r, 5)
+		if from == (Pos2{}) {
+			continue
+		}
+		a, b := randomPos2(r, 10), randomPos2(r, 10)
+		if got, want := CompareAngle(from, a, b), bruteCompareAngle(from, a, b); got != want {
+			t.Fatalf("CompareAngle(%v, %v, %v) = %d; want %d", from, a, b, got, want)
+		}
+	}
+}
+
+func TestSortByAngle_BruteForce(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	for range 1000 {
+		center := randomPos2(r, 5)
+		from := randomPos2(r, 3)
+		if from == (Pos2{}) {
+			from = Pos2{X: 1}
+		}
+		ps := make([]Pos2, r.IntN(30))
+		for i := range ps {
+			ps[i] = randomPos2(r, 10)
+		}
+		got := slices.Clone(ps)
+		SortByAngle(got, center, from)
+		for i := 1; i < len(got); i++ {
+			a, b := got[i-1].Sub(center), got[i].Sub(center)
+			c := bruteCompareAngle(from, a, b)
+			if c > 0 || c == 0 && a.L1Norm() > b.L1Norm() {
+				t.Fatalf("SortByAngle(%v, center=%v, from=%v) = %v: %v comes before %v", ps, center, from, got, got[i-1], got[i])
+			}
+		}
+	}
+}
+
+// bruteVisible reports whether p is visible from center by checking every
+// lattice point strictly between them.
+func bruteVisible(center, p Pos2, ps []Pos2) bool {
+	d := p.Sub(center)
+	if d == (Pos2{}) {
+		return false
+	}
+	n := gcd(d.X, d.Y)
+	step := d.Reduce()
+	for k := 1; k < n; k++ {
+		q := Pos2{X: center.X + k*step.X, Y: center.Y + k*step.Y}
+		if slices.Contains(ps, q) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestVisible_BruteForce(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+	for range 1000 {
+		center := randomPos2(r, 5)
+		ps := make([]Pos2, r.IntN(40))
+		for i := range ps {
+			ps[i] = randomPos2(r, 8)
+		}
+		var want []Pos2
+		for _, p := range ps {
+			if bruteVisible(center, p, ps) {
+				want = append(want, p)
+			}
+		}
+		if diff := cmp.Diff(want, Visible(center, ps)); diff != "" {
+			t.Fatalf("Visible(%v, %v): unexpected result (-want +got)\n%s", center, ps, diff)
+		}
+	}
+}
+
+// parsePoints returns the positions of '#' in s, with X being the column and Y
+// the row.
+func parsePoints(s string) []Pos2 {
+	var ps []Pos2
+	for y, line := range strings.Split(strings.TrimSpace(s), "\n") {
+		for x, c := range line {
+			if c == '#' {
+				ps = append(ps, Pos2{X: x, Y: y})
+			}
+		}
+	}
+	return ps
+}
+
+func TestSweep(t *testing.T) {
+	// The laser example from Advent of Code 2019 day 10. Y points down, so
+	// sweeping clockwise starting upwards means starting at (0, -1).
+	ps := parsePoints(`
+.#....#####...#..
+##...##.#####..##
+##...#...#.#####.
+..#.....#...###..
+..#.#.....#....##
+`)
+	center := Pos2{X: 8, Y: 3}
+	got := Sweep(center, ps, Pos2{X: 0, Y: -1})
+	want := []Pos2{
+		// First rotation.
+		{8, 1}, {9, 0}, {9, 1}, {10, 0}, {9, 2}, {11, 1}, {12, 1}, {11, 2}, {15, 1},
+		{12, 2}, {13, 2}, {14, 2}, {15, 2}, {12, 3}, {16, 4}, {15, 4}, {10, 4}, {4, 4},
+		{2, 4}, {2, 3}, {0, 2}, {1, 2}, {0, 1}, {1, 1}, {5, 2}, {1, 0}, {5, 1},
+		{6, 1}, {6, 0}, {7, 0}, {8, 0}, {10, 1}, {14, 0}, {16, 1}, {13, 3}, {14, 3},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Sweep(): unexpected order (-want +got)\n%s", diff)
+	}
+}
@@ -0,0 +1,263 @@
+package geometry
+
+import (
+	"fmt"
+	"iter"
+
+	"go.saser.se/adventofgo/math/span"
+)
+
+// Rect is an axis-aligned rectangle of integer points, made up of a span of
+// coordinates along each axis. A Rect is empty if either span is.
+type Rect struct {
+	X, Y span.Span[int]
+}
+
+// RectOf returns the smallest rectangle containing both a and b, which are
+// typically opposite corners like "0,0 through 999,999" in a puzzle input.
+func RectOf(a, b Pos2) Rect {
+	lo, hi := a.Min(b), a.Max(b)
+	return Rect{
+		X: span.New(lo.X, hi.X+1),
+		Y: span.New(lo.Y, hi.Y+1),
+	}
+}
+
+// Empty reports whether r contains no points.
+func (r Rect) Empty() bool {
+	return r.X.Len() == 0 || r.Y.Len() == 0
+}
+
+// Area returns the number of points in r.
+func (r Rect) Area() int {
+	if r.Empty() {
+		return 0
+	}
+	return r.X.Len() * r.Y.Len()
+}
+
+// Contains reports whether p is in r.
+func (r Rect) Contains(p Pos2) bool {
+	return r.X.Contains(p.X) == 0 && r.Y.Contains(p.Y) == 0
+}
+
+// ContainsRect reports whether every point in s is also in r. An empty
+// rectangle is contained in every rectangle.
+func (r Rect) ContainsRect(s Rect) bool {
+	return s.Empty() || r.Intersect(s) == s
+}
+
+// Intersect returns the rectangle of points in both r and s. If there are no
+// such points, Intersect returns the zero Rect.
+func (r Rect) Intersect(s Rect) Rect {
+	i := Rect{
+		X: span.Intersection(r.X, s.X),
+		Y: span.Intersection(r.Y, s.Y),
+	}
+	if i.Empty() {
+		return Rect{}
+	}
+	return i
+}
+
+// Sub returns the points in r that are not in s, as at most 4 disjoint
+// rectangles.
+func (r Rect) Sub(s Rect) []Rect {
+	i := r.Intersect(s)
+	if i.Empty() {
+		if r.Empty() {
+			return nil
+		}
+		return []Rect{r}
+	}
+	var pieces []Rect
+	// Cut off the parts of r on either side of i along X, and then the parts
+	// of what's left on either side of i along Y.
+	for _, x := range []span.Span[int]{span.New(r.X.Start, i.X.Start), span.New(i.X.End, r.X.End)} {
+		if x.Len() > 0 {
+			pieces = append(pieces, Rect{X: x, Y: r.Y})
+		}
+	}
+	for _, y := range []span.Span[int]{span.New(r.Y.Start, i.Y.Start), span.New(i.Y.End, r.Y.End)} {
+		if y.Len() > 0 {
+			pieces = append(pieces, Rect{X: i.X, Y: y})
+		}
+	}
+	return pieces
+}
+
+// All iterates over the points in r, ordered by Y and then by X.
+func (r Rect) All() iter.Seq[Pos2] {
+	return func(yield func(Pos2) bool) {
+		if r.Empty() {
+			return
+		}
+		for y := r.Y.Start; y < r.Y.End; y++ {
+			for x := r.X.Start; x < r.X.End; x++ {
+				if !yield(Pos2{X: x, Y: y}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (r Rect) String() string {
+	return fmt.Sprintf("%v×%v", r.X, r.Y)
+}
+
+// UnionArea returns the number of points that are in at least one of the
+// rectangles.
+func UnionArea(rs []Rect) int {
+	area := 0
+	for _, r := range Disjoint(rs) {
+		area += r.Area()
+	}
+	return area
+}
+
+// Disjoint returns rectangles covering the same points as rs, where no two
+// rectangles overlap. Each rectangle in rs is cut by the ones before it.
+func Disjoint(rs []Rect) []Rect {
+	return disjoint(rs)
+}
+
+// subtractable is a shape that Sub can cut pieces out of, like Rect and Box.
+type subtractable[T any] interface {
+	Sub(T) []T
+}
+
+// subAll returns the points in x that are in none of ys, as disjoint pieces.
+func subAll[T subtractable[T]](x T, ys []T) []T {
+	pieces := []T{x}
+	for _, y := range ys {
+		if len(pieces) == 0 {
+			break
+		}
+		var next []T
+		for _, p := range pieces {
+			next = append(next, p.Sub(y)...)
+		}
+		pieces = next
+	}
+	return pieces
+}
+
+// disjoint implements Disjoint and DisjointBoxes.
+func disjoint[T subtractable[T]](xs []T) []T {
+	var d []T
+	for _, x := range xs {
+		d = append(d, subAll(x, d)...)
+	}
+	return d
+}
+
+// Box is an axis-aligned cuboid of integer points, made up of a span of
+// coordinates along each axis. A Box is empty if any span is.
+type Box struct {
+	X, Y, Z span.Span[int]
+}
+
+// BoxOf returns the smallest box containing both a and b, which are typically
+// opposite corners.
+func BoxOf(a, b Pos3) Box {
+	lo, hi := a.Min(b), a.Max(b)
+	return Box{
+		X: span.New(lo.X, hi.X+1),
+		Y: span.New(lo.Y, hi.Y+1),
+		Z: span.New(lo.Z, hi.Z+1),
+	}
+}
+
+// Empty reports whether b contains no points.
+func (b Box) Empty() bool {
+	return b.X.Len() == 0 || b.Y.Len() == 0 || b.Z.Len() == 0
+}
+
+// Volume returns the number of points in b.
+func (b Box) Volume() int {
+	if b.Empty() {
+		return 0
+	}
+	return b.X.Len() * b.Y.Len() * b.Z.Len()
+}
+
+// Contains reports whether p is in b.
+func (b Box) Contains(p Pos3) bool {
+	return b.X.Contains(p.X) == 0 && b.Y.Contains(p.Y) == 0 && b.Z.Contains(p.Z) == 0
+}
+
+// ContainsBox reports whether every point in c is also in b. An empty box is
+// contained in every box.
+func (b Box) ContainsBox(c Box) bool {
+	return c.Empty() || b.Intersect(c) == c
+}
+
+// Intersect returns the box of points in both b and c. If there are no such
+// points, Intersect returns the zero Box.
+func (b Box) Intersect(c Box) Box {
+	i := Box{
+		X: span.Intersection(b.X, c.X),
+		Y: span.Intersection(b.Y, c.Y),
+		Z: span.Intersection(b.Z, c.Z),
+	}
+	if i.Empty() {
+		return Box{}
+	}
+	return i
+}
+
+// Sub returns the points in b that are not in c, as at most 6 disjoint boxes.
+func (b Box) Sub(c Box) []Box {
+	i := b.Intersect(c)
+	if i.Empty() {
+		if b.Empty() {
+			return nil
+		}
+		return []Box{b}
+	}
+	var pieces []Box
+	// Cut off the parts of b on either side of i along X, then the parts of
+	// what's left along Y, and then along Z.
+	for _, x := range []span.Span[int]{span.New(b.X.Start, i.X.Start), span.New(i.X.End, b.X.End)} {
+		if x.Len() > 0 {
+			pieces = append(pieces, Box{X: x, Y: b.Y, Z: b.Z})
+		}
+	}
+	for _, y := range []span.Span[int]{span.New(b.Y.Start, i.Y.Start), span.New(i.Y.End, b.Y.End)} {
+		if y.Len() > 0 {
+			pieces = append(pieces, Box{X: i.X, Y: y, Z: b.Z})
+		}
+	}
+	for _, z := range []span.Span[int]{span.New(b.Z.Start, i.Z.Start), span.New(i.Z.End, b.Z.End)} {
+		if z.Len() > 0 {
+			pieces = append(pieces, Box{X: i.X, Y: i.Y, Z: z})
+		}
+	}
+	return pieces
+}
+
+func (b Box) String() string {
+	return fmt.Sprintf("%v×%v×%v", b.X, b.Y, b.Z)
+}
+
+// UnionVolume returns the number of points that are in at least one of the
+// boxes.
+func UnionVolume(bs []Box) int {
+	volume := 0
+	for _, b := range DisjointBoxes(bs) {
+		volume += b.Volume()
+	}
+	return volume
+}
+
+// DisjointBoxes returns boxes covering the same points as bs, where no two
+// boxes overlap. Each box in bs is cut by the ones before it.
+//
+// For puzzles where boxes are both added and removed, like turning cubes on
+// and off in 2021 day 22, keep a list of disjoint boxes: cut every box in the
+// list with Sub when a new box comes along, and then add the new box if it
+// turns cubes on.
+func DisjointBoxes(bs []Box) []Box {
+	return disjoint(bs)
+}
@@ -0,0 +1,219 @@
+package geometry
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func randomRect(r *rand.Rand) Rect {
+	corner := func() Pos2 { return Pos2{X: r.IntN(12) - 4, Y: r.IntN(12) - 4} }
+	return RectOf(corner(), corner())
+}
+
+func randomBox(r *rand.Rand) Box {
+	corner := func() Pos3 { return Pos3{X: r.IntN(8) - 2, Y: r.IntN(8) - 2, Z: r.IntN(8) - 2} }
+	return BoxOf(corner(), corner())
+}
+
+// rectPoints and boxPoints cover every point of the random rectangles and boxes
+// above, and then some.
+func rectPoints(yield func(Pos2) bool) {
+	for y := -6; y < 10; y++ {
+		for x := -6; x < 10; x++ {
+			if !yield(Pos2{X: x, Y: y}) {
+				return
+			}
+		}
+	}
+}
+
+func boxPoints(yield func(Pos3) bool) {
+	for z := -4; z < 8; z++ {
+		for y := -4; y < 8; y++ {
+			for x := -4; x < 8; x++ {
+				if !yield(Pos3{X: x, Y: y, Z: z}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func TestRectOf(t *testing.T) {
+	r := RectOf(Pos2{X: 499, Y: 499}, Pos2{X: 0, Y: 0})
+	if got, want := r.Area(), 500*500; got != want {
+		t.Errorf("Area() = %d; want %d", got, want)
+	}
+	for _, p := range []Pos2{{X: 0, Y: 0}, {X: 499, Y: 499}, {X: 0, Y: 499}} {
+		if !r.Contains(p) {
+			t.Errorf("%v.Contains(%v) = false; want true", r, p)
+		}
+	}
+	for _, p := range []Pos2{{X: -1, Y: 0}, {X: 500, Y: 499}, {X: 0, Y: 500}} {
+		if r.Contains(p) {
+			t.Errorf("%v.Contains(%v) = true; want false", r, p)
+		}
+	}
+	if got, want := RectOf(Pos2{X: 3, Y: 3}, Pos2{X: 3, Y: 3}).Area(), 1; got != want {
+		t.Errorf("area of a single point = %d; want %d", got, want)
+	}
+}
+
+func TestRect_Random(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 1000 {
+		a, b := randomRect(r), randomRect(r)
+		i := a.Intersect(b)
+		diff := a.Sub(b)
+		wantArea, wantIntersect := 0, 0
+		for p := range rectPoints {
+			inA, inB := a.Contains(p), b.Contains(p)
+			if inA {
+				wantArea++
+			}
+			if inA && inB {
+				wantIntersect++
+			}
+			if got, want := i.Contains(p), inA && inB; got != want {
+				t.Fatalf("%v.Intersect(%v).Contains(%v) = %v; want %v", a, b, p, got, want)
+			}
+			n := 0
+			for _, d := range diff {
+				if d.Contains(p) {
+					n++
+				}
+			}
+			if want := inA && !inB; n > 1 || (n == 1) != want {
+				t.Fatalf("%v.Sub(%v) = %v covers %v %d times; want in difference: %v", a, b, diff, p, n, want)
+			}
+		}
+		if got := a.Area(); got != wantArea {
+			t.Errorf("%v.Area() = %d; want %d", a, got, wantArea)
+		}
+		if got := i.Area(); got != wantIntersect {
+			t.Errorf("%v.Intersect(%v).Area() = %d; want %d", a, b, got, wantIntersect)
+		}
+		if len(diff) > 4 {
+			t.Errorf("%v.Sub(%v) returned %d pieces; want at most 4", a, b, len(diff))
+		}
+		if got, want := a.ContainsRect(b), wantIntersect == b.Area(); got != want {
+			t.Errorf("%v.ContainsRect(%v) = %v; want %v", a, b, got, want)
+		}
+	}
+}
+
+func TestUnionArea(t *testing.T) {
+	// The example from 2018 day 3, where 4 square inches of fabric are in
+	// two claims, and the total area is 32.
+	claims := []Rect{
+		RectOf(Pos2{X: 1, Y: 3}, Pos2{X: 4, Y: 6}),
+		RectOf(Pos2{X: 3, Y: 1}, Pos2{X: 6, Y: 4}),
+		RectOf(Pos2{X: 5, Y: 5}, Pos2{X: 6, Y: 6}),
+	}
+	if got, want := UnionArea(claims), 32; got != want {
+		t.Errorf("UnionArea(claims) = %d; want %d", got, want)
+	}
+
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 200 {
+		rs := make([]Rect, r.IntN(8))
+		for i := range rs {
+			rs[i] = randomRect(r)
+		}
+		want := 0
+		for p := range rectPoints {
+			for _, rect := range rs {
+				if rect.Contains(p) {
+					want++
+					break
+				}
+			}
+		}
+		if got := UnionArea(rs); got != want {
+			t.Errorf("UnionArea(%v) = %d; want %d", rs, got, want)
+		}
+	}
+}
+
+func TestBox_Random(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 300 {
+		a, b := randomBox(r), randomBox(r)
+		i := a.Intersect(b)
+		diff := a.Sub(b)
+		wantVolume, wantIntersect := 0, 0
+		for p := range boxPoints {
+			inA, inB := a.Contains(p), b.Contains(p)
+			if inA {
+				wantVolume++
+			}
+			if inA && inB {
+				wantIntersect++
+			}
+			if got, want := i.Contains(p), inA && inB; got != want {
+				t.Fatalf("%v.Intersect(%v).Contains(%v) = %v; want %v", a, b, p, got, want)
+			}
+			n := 0
+			for _, d := range diff {
+				if d.Contains(p) {
+					n++
+				}
+			}
+			if want := inA && !inB; n > 1 || (n == 1) != want {
+				t.Fatalf("%v.Sub(%v) = %v covers %v %d times; want in difference: %v", a, b, diff, p, n, want)
+			}
+		}
+		if got := a.Volume(); got != wantVolume {
+			t.Errorf("%v.Volume() = %d; want %d", a, got, wantVolume)
+		}
+		if got := i.Volume(); got != wantIntersect {
+			t.Errorf("%v.Intersect(%v).Volume() = %d; want %d", a, b, got, wantIntersect)
+		}
+		if len(diff) > 6 {
+			t.Errorf("%v.Sub(%v) returned %d pieces; want at most 6", a, b, len(diff))
+		}
+		if got, want := a.ContainsBox(b), wantIntersect == b.Volume(); got != want {
+			t.Errorf("%v.ContainsBox(%v) = %v; want %v", a, b, got, want)
+		}
+	}
+}
+
+func TestUnionVolume(t *testing.T) {
+	// The first two steps of the small example from 2021 day 22, which turn on
+	// 27 and then 19 more cubes.
+	on := []Box{
+		BoxOf(Pos3{X: 10, Y: 10, Z: 10}, Pos3{X: 12, Y: 12, Z: 12}),
+		BoxOf(Pos3{X: 11, Y: 11, Z: 11}, Pos3{X: 13, Y: 13, Z: 13}),
+	}
+	if got, want := UnionVolume(on), 46; got != want {
+		t.Errorf("UnionVolume(on) = %d; want %d", got, want)
+	}
+
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 100 {
+		bs := make([]Box, r.IntN(8))
+		for i := range bs {
+			bs[i] = randomBox(r)
+		}
+		want := 0
+		for p := range boxPoints {
+			for _, b := range bs {
+				if b.Contains(p) {
+					want++
+					break
+				}
+			}
+		}
+		if got := UnionVolume(bs); got != want {
+			t.Errorf("UnionVolume(%v) = %d; want %d", bs, got, want)
+		}
+		disjoint := DisjointBoxes(bs)
+		for i, a := range disjoint {
+			for _, b := range disjoint[i+1:] {
+				if !a.Intersect(b).Empty() {
+					t.Errorf("DisjointBoxes(%v) returned overlapping boxes %v and %v", bs, a, b)
+				}
+			}
+		}
+	}
+}
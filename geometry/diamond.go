@@ -0,0 +1,130 @@
+package geometry
+
+import (
+	"fmt"
+	"iter"
+
+	"go.saser.se/adventofgo/math/span"
+)
+
+// Diamond is the set of points within a Manhattan distance of Radius from
+// Center, like the area a sensor can see in 2022 day 15. It's called a diamond
+// because of its shape:
+//
+//	...#...
+//	..###..
+//	.##C##.
+//	..###..
+//	...#...
+//
+// A Diamond with a negative radius is empty.
+type Diamond struct {
+	Center Pos2
+	Radius int
+}
+
+// Contains reports whether p is in d.
+func (d Diamond) Contains(p Pos2) bool {
+	return p.Sub(d.Center).L1Norm() <= d.Radius
+}
+
+// Intersects reports whether d and e have any point in common.
+func (d Diamond) Intersects(e Diamond) bool {
+	return d.Radius >= 0 && e.Radius >= 0 && d.Center.Sub(e.Center).L1Norm() <= d.Radius+e.Radius
+}
+
+// Row returns the X coordinates of the points in d that have the given Y
+// coordinate, which is empty if d doesn't reach that row.
+func (d Diamond) Row(y int) span.Span[int] {
+	w := d.Radius - abs(y-d.Center.Y)
+	return span.New(d.Center.X-w, d.Center.X+w+1)
+}
+
+// Rotated returns d rotated 45 degrees by Rotate45, which turns it into a
+// square. Not every point in the square is the rotation of a point in d; see
+// Rotate45.
+func (d Diamond) Rotated() Rect {
+	c := d.Center.Rotate45()
+	return Rect{
+		X: span.New(c.X-d.Radius, c.X+d.Radius+1),
+		Y: span.New(c.Y-d.Radius, c.Y+d.Radius+1),
+	}
+}
+
+func (d Diamond) String() string {
+	return fmt.Sprintf("%v±%d", d.Center, d.Radius)
+}
+
+// Coverage returns the X coordinates of the points on row y that are in at
+// least one of the diamonds.
+func Coverage(ds []Diamond, y int) *span.Set[int] {
+	s := new(span.Set[int])
+	for _, d := range ds {
+		s.Add(d.Row(y))
+	}
+	return s
+}
+
+// Uncovered iterates over the points in bounds that aren't in any of the
+// diamonds, as spans of X coordinates on a row: for each span it yields the
+// row's Y coordinate and the span. The spans don't overlap, but they come in no
+// particular order, and the uncovered points on a row may be split over
+// several spans.
+//
+// Uncovered doesn't go row by row. It rotates the diamonds into squares, cuts
+// them out of a square around bounds, and only looks at the rows of what's
+// left. The time it takes depends on the number of diamonds and on how much of
+// bounds is uncovered, but not on the size of bounds, so it works even when
+// bounds has millions of rows, like in 2022 day 15.
+func Uncovered(ds []Diamond, bounds Rect) iter.Seq2[int, span.Span[int]] {
+	return func(yield func(int, span.Span[int]) bool) {
+		if bounds.Empty() {
+			return
+		}
+		// The rotations of the points in bounds lie within the square spanned
+		// by the rotations of its corners.
+		lo := Pos2{X: bounds.X.Start, Y: bounds.Y.Start}
+		hi := Pos2{X: bounds.X.End - 1, Y: bounds.Y.End - 1}
+		around := Rect{
+			X: span.New(lo.X-hi.Y, hi.X-lo.Y+1),
+			Y: span.New(lo.X+lo.Y, hi.X+hi.Y+1),
+		}
+		squares := make([]Rect, len(ds))
+		for i, d := range ds {
+			squares[i] = d.Rotated()
+		}
+		for _, r := range subAll(around, squares) {
+			for y, xs := range unrotatedRows(r, bounds) {
+				if !yield(y, xs) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// unrotatedRows iterates over the points in bounds whose rotation by Rotate45
+// is in r, as spans of X coordinates on each row. Every row it yields is
+// non-empty.
+func unrotatedRows(r Rect, bounds Rect) iter.Seq2[int, span.Span[int]] {
+	return func(yield func(int, span.Span[int]) bool) {
+		// A point is in r if u0 <= x-y < u1 and v0 <= x+y < v1, so on row y
+		// its X coordinate is in [max(u0+y, v0-y), min(u1+y, v1-y)), and it
+		// must also be in bounds.X. Each of the lower ends must be below each
+		// of the upper ends, which limits y.
+		u0, u1 := r.X.Start, r.X.End
+		v0, v1 := r.Y.Start, r.Y.End
+		x0, x1 := bounds.X.Start, bounds.X.End
+		// Unlike /, >> rounds negative numbers down.
+		floorHalf := func(a int) int { return a >> 1 }
+		ceilHalf := func(a int) int { return (a + 1) >> 1 }
+		yLo := max(bounds.Y.Start, ceilHalf(v0-u1+1), v0-x1+1, x0-u1+1)
+		yHi := min(bounds.Y.End-1, floorHalf(v1-u0-1), x1-u0-1, v1-x0-1)
+		for y := yLo; y <= yHi; y++ {
+			xs := span.New(max(u0+y, v0-y, x0), min(u1+y, v1-y, x1))
+			if !yield(y, xs) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package geometry
+
+import (
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"go.saser.se/adventofgo/math/span"
+)
+
+// sensors returns the diamonds seen by the sensors in the example from 2022 day
+// 15, and the beacons they found.
+func sensors() ([]Diamond, []Pos2) {
+	pairs := [][4]int{
+		{2, 18, -2, 15},
+		{9, 16, 10, 16},
+		{13, 2, 15, 3},
+		{12, 14, 10, 16},
+		{10, 20, 10, 16},
+		{14, 17, 10, 16},
+		{8, 7, 2, 10},
+		{2, 0, 2, 10},
+		{0, 11, 2, 10},
+		{20, 14, 25, 17},
+		{17, 20, 21, 22},
+		{16, 7, 15, 3},
+		{14, 3, 15, 3},
+		{20, 1, 15, 3},
+	}
+	var (
+		ds      []Diamond
+		beacons []Pos2
+	)
+	for _, p := range pairs {
+		sensor, beacon := Pos2{X: p[0], Y: p[1]}, Pos2{X: p[2], Y: p[3]}
+		ds = append(ds, Diamond{Center: sensor, Radius: beacon.Sub(sensor).L1Norm()})
+		beacons = append(beacons, beacon)
+	}
+	return ds, beacons
+}
+
+func TestCoverage_Beacons(t *testing.T) {
+	ds, beacons := sensors()
+	const y = 10
+	cov := Coverage(ds, y)
+	for _, b := range beacons {
+		if b.Y == y {
+			cov.Remove(span.New(b.X, b.X+1))
+		}
+	}
+	if got, want := cov.Len(), 26; got != want {
+		t.Errorf("positions on row %d where there can't be a beacon: got %d; want %d", y, got, want)
+	}
+}
+
+func TestUncovered_DistressBeacon(t *testing.T) {
+	ds, _ := sensors()
+	var got []Pos2
+	for y, xs := range Uncovered(ds, RectOf(Pos2{X: 0, Y: 0}, Pos2{X: 20, Y: 20})) {
+		for x := xs.Start; x < xs.End; x++ {
+			got = append(got, Pos2{X: x, Y: y})
+		}
+	}
+	if want := (Pos2{X: 14, Y: 11}); len(got) != 1 || got[0] != want {
+		t.Errorf("Uncovered() = %v; want [%v]", got, want)
+	}
+}
+
+func TestUncovered_Huge(t *testing.T) {
+	// Going row by row would take a long time.
+	bounds := RectOf(Pos2{X: 0, Y: 0}, Pos2{X: 4_000_000, Y: 4_000_000})
+	ds := []Diamond{
+		{Center: Pos2{X: 2_000_000, Y: 2_000_000}, Radius: 4_000_000},
+	}
+	for y, xs := range Uncovered(ds, bounds) {
+		t.Errorf("Uncovered() yielded row %d, %v; want nothing", y, xs)
+	}
+	// Every point is less than 4,000,000 away from one of the corners,
+	// except the center.
+	ds = []Diamond{
+		{Center: Pos2{X: 0, Y: 0}, Radius: 4_000_000 - 1},
+		{Center: Pos2{X: 4_000_000, Y: 4_000_000}, Radius: 4_000_000 - 1},
+		{Center: Pos2{X: 4_000_000, Y: 0}, Radius: 4_000_000 - 1},
+		{Center: Pos2{X: 0, Y: 4_000_000}, Radius: 4_000_000 - 1},
+	}
+	var got []Pos2
+	for y, xs := range Uncovered(ds, bounds) {
+		for x := xs.Start; x < xs.End; x++ {
+			got = append(got, Pos2{X: x, Y: y})
+		}
+	}
+	if want := (Pos2{X: 2_000_000, Y: 2_000_000}); len(got) != 1 || got[0] != want {
+		t.Errorf("Uncovered() = %v; want [%v]", got, want)
+	}
+}
+
+func TestUncovered_Random(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 300 {
+		ds := make([]Diamond, r.IntN(5))
+		for i := range ds {
+			ds[i] = Diamond{Center: Pos2{X: r.IntN(16) - 8, Y: r.IntN(16) - 8}, Radius: r.IntN(7) - 1}
+		}
+		bounds := RectOf(Pos2{X: r.IntN(12) - 6, Y: r.IntN(12) - 6}, Pos2{X: r.IntN(12) - 6, Y: r.IntN(12) - 6})
+		want := make(map[Pos2]bool)
+		for p := range bounds.All() {
+			covered := false
+			for _, d := range ds {
+				covered = covered || d.Contains(p)
+			}
+			if !covered {
+				want[p] = true
+			}
+		}
+		got := make(map[Pos2]bool)
+		for y, xs := range Uncovered(ds, bounds) {
+			if xs.Len() == 0 {
+				t.Errorf("Uncovered(%v, %v) yielded an empty span on row %d", ds, bounds, y)
+			}
+			for x := xs.Start; x < xs.End; x++ {
+				p := Pos2{X: x, Y: y}
+				if got[p] {
+					t.Errorf("Uncovered(%v, %v) yielded %v more than once", ds, bounds, p)
+				}
+				got[p] = true
+			}
+		}
+		if !maps.Equal(got, want) {
+			t.Errorf("Uncovered(%v, %v) = %v; want %v", ds, bounds, slices.Collect(maps.Keys(got)), slices.Collect(maps.Keys(want)))
+		}
+	}
+}
+
+func TestDiamond_Random(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	randomDiamond := func() Diamond {
+		return Diamond{Center: Pos2{X: r.IntN(10) - 5, Y: r.IntN(10) - 5}, Radius: r.IntN(6) - 1}
+	}
+	for range 300 {
+		d, e := randomDiamond(), randomDiamond()
+		square := d.Rotated()
+		intersects := false
+		for p := range rectPoints {
+			in := d.Contains(p)
+			if got := d.Row(p.Y).Contains(p.X) == 0; got != in {
+				t.Fatalf("%v.Row(%d).Contains(%d) = %v; want %v", d, p.Y, p.X, got, in)
+			}
+			if got := square.Contains(p.Rotate45()); got != in {
+				t.Fatalf("%v.Rotated().Contains(%v.Rotate45()) = %v; want %v", d, p, got, in)
+			}
+			if q, ok := p.Rotate45().Unrotate45(); !ok || q != p {
+				t.Fatalf("%v.Rotate45().Unrotate45() = %v, %v; want %v, true", p, q, ok, p)
+			}
+			if (p.X+p.Y)%2 != 0 {
+				if q, ok := p.Unrotate45(); ok {
+					t.Fatalf("%v.Unrotate45() = %v, true; want false", p, q)
+				}
+			}
+			intersects = intersects || in && e.Contains(p)
+		}
+		if got := d.Intersects(e); got != intersects {
+			t.Errorf("%v.Intersects(%v) = %v; want %v", d, e, got, intersects)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+package geometry
+
+import (
+	"fmt"
+	"strconv"
+
+	"go.saser.se/adventofgo/math/rational"
+)
+
+// RatPos2 is a point in 2D space with rational coordinates, like the point
+// where two lines cross.
+type RatPos2 struct {
+	X, Y rational.Rat
+}
+
+// Add returns a + b.
+func (a RatPos2) Add(b RatPos2) RatPos2 {
+	return RatPos2{X: a.X.Add(b.X), Y: a.Y.Add(b.Y)}
+}
+
+// Sub returns a - b.
+func (a RatPos2) Sub(b RatPos2) RatPos2 {
+	return RatPos2{X: a.X.Sub(b.X), Y: a.Y.Sub(b.Y)}
+}
+
+// Scale returns p with every coordinate multiplied by k.
+func (p RatPos2) Scale(k rational.Rat) RatPos2 {
+	return RatPos2{X: p.X.Mul(k), Y: p.Y.Mul(k)}
+}
+
+// Dot returns the dot product of a and b.
+func (a RatPos2) Dot(b RatPos2) rational.Rat {
+	return a.X.Mul(b.X).Add(a.Y.Mul(b.Y))
+}
+
+// Cross returns the Z coordinate of the cross product of a and b, as for
+// Pos2.Cross.
+func (a RatPos2) Cross(b RatPos2) rational.Rat {
+	return a.X.Mul(b.Y).Sub(a.Y.Mul(b.X))
+}
+
+// Equal reports whether a and b are the same point.
+func (a RatPos2) Equal(b RatPos2) bool {
+	return a.X.Equal(b.X) && a.Y.Equal(b.Y)
+}
+
+func (p RatPos2) String() string {
+	return fmt.Sprintf("(%v, %v)", p.X, p.Y)
+}
+
+// RatPos3 is a point in 3D space with rational coordinates.
+type RatPos3 struct {
+	X, Y, Z rational.Rat
+}
+
+// Add returns a + b.
+func (a RatPos3) Add(b RatPos3) RatPos3 {
+	return RatPos3{X: a.X.Add(b.X), Y: a.Y.Add(b.Y), Z: a.Z.Add(b.Z)}
+}
+
+// Sub returns a - b.
+func (a RatPos3) Sub(b RatPos3) RatPos3 {
+	return RatPos3{X: a.X.Sub(b.X), Y: a.Y.Sub(b.Y), Z: a.Z.Sub(b.Z)}
+}
+
+// Scale returns p with every coordinate multiplied by k.
+func (p RatPos3) Scale(k rational.Rat) RatPos3 {
+	return RatPos3{X: p.X.Mul(k), Y: p.Y.Mul(k), Z: p.Z.Mul(k)}
+}
+
+// Dot returns the dot product of a and b.
+func (a RatPos3) Dot(b RatPos3) rational.Rat {
+	return a.X.Mul(b.X).Add(a.Y.Mul(b.Y)).Add(a.Z.Mul(b.Z))
+}
+
+// Cross returns the cross product of a and b, as for Pos3.Cross.
+func (a RatPos3) Cross(b RatPos3) RatPos3 {
+	return RatPos3{
+		X: a.Y.Mul(b.Z).Sub(a.Z.Mul(b.Y)),
+		Y: a.Z.Mul(b.X).Sub(a.X.Mul(b.Z)),
+		Z: a.X.Mul(b.Y).Sub(a.Y.Mul(b.X)),
+	}
+}
+
+// Equal reports whether a and b are the same point.
+func (a RatPos3) Equal(b RatPos3) bool {
+	return a.X.Equal(b.X) && a.Y.Equal(b.Y) && a.Z.Equal(b.Z)
+}
+
+func (p RatPos3) String() string {
+	return fmt.Sprintf("(%v, %v, %v)", p.X, p.Y, p.Z)
+}
+
+// isZero reports whether p is the origin.
+func (p RatPos3) isZero() bool {
+	return p.X.Sign() == 0 && p.Y.Sign() == 0 && p.Z.Sign() == 0
+}
+
+// LineRelation is how two lines relate to each other.
+type LineRelation int
+
+const (
+	// Crossing lines meet in exactly one point.
+	Crossing LineRelation = iota
+	// Parallel lines go in the same direction and never meet.
+	Parallel
+	// Collinear lines are the same line.
+	Collinear
+	// Skew lines go in different directions but never meet, which can only
+	// happen in 3D.
+	Skew
+)
+
+func (r LineRelation) String() string {
+	switch r {
+	case Crossing:
+		return "Crossing"
+	case Parallel:
+		return "Parallel"
+	case Collinear:
+		return "Collinear"
+	case Skew:
+		return "Skew"
+	default:
+		return "LineRelation(" + strconv.Itoa(int(r)) + ")"
+	}
+}
+
+// Line2 is the line through P in direction D, like the path of a hailstone
+// that starts at P and moves D each nanosecond. D must not be zero.
+type Line2 struct {
+	P, D Pos2
+}
+
+// At returns the point P + t*D.
+func (l Line2) At(t rational.Rat) RatPos2 {
+	return l.P.Rat().Add(l.D.Rat().Scale(t))
+}
+
+// IntersectLines returns how l and m relate, and if they are Crossing, the t
+// and u where l.At(t) and m.At(u) are the point where they cross. To find
+// where two rays or segments meet, check whether t and u are within their
+// ranges, like t >= 0 for a ray going forward from P.
+//
+// All arithmetic is exact, so the result is correct even for coordinates so
+// large that their products overflow an int.
+func IntersectLines(l, m Line2) (t, u rational.Rat, rel LineRelation) {
+	d, e := l.D.Rat(), m.D.Rat()
+	w := m.P.Rat().Sub(l.P.Rat())
+	denom := d.Cross(e)
+	if denom.Sign() == 0 {
+		if w.Cross(d).Sign() == 0 {
+			return rational.Rat{}, rational.Rat{}, Collinear
+		}
+		return rational.Rat{}, rational.Rat{}, Parallel
+	}
+	// l.P + t*d = m.P + u*e. Taking the cross product of both sides with e
+	// gets rid of u, and with d gets rid of t.
+	return w.Cross(e).Div(denom), w.Cross(d).Div(denom), Crossing
+}
+
+// Line3 is the line through P in direction D. D must not be zero.
+type Line3 struct {
+	P, D Pos3
+}
+
+// At returns the point P + t*D.
+func (l Line3) At(t rational.Rat) RatPos3 {
+	return l.P.Rat().Add(l.D.Rat().Scale(t))
+}
+
+// IntersectLines3 is like IntersectLines for lines in 3D, which may also be
+// Skew.
+func IntersectLines3(l, m Line3) (t, u rational.Rat, rel LineRelation) {
+	d, e := l.D.Rat(), m.D.Rat()
+	w := m.P.Rat().Sub(l.P.Rat())
+	n := d.Cross(e)
+	if n.isZero() {
+		if w.Cross(d).isZero() {
+			return rational.Rat{}, rational.Rat{}, Collinear
+		}
+		return rational.Rat{}, rational.Rat{}, Parallel
+	}
+	// The lines only meet if w lies in the plane spanned by d and e.
+	if w.Dot(n).Sign() != 0 {
+		return rational.Rat{}, rational.Rat{}, Skew
+	}
+	nn := n.Dot(n)
+	return w.Cross(e).Dot(n).Div(nn), w.Cross(d).Dot(n).Div(nn), Crossing
+}
+
+// Segment is the line segment between A and B, including both.
+type Segment struct {
+	A, B Pos2
+}
+
+// Contains reports whether p is on s.
+func (s Segment) Contains(p Pos2) bool {
+	d, w := s.B.Rat().Sub(s.A.Rat()), p.Rat().Sub(s.A.Rat())
+	return d.Cross(w).Sign() == 0 &&
+		min(s.A.X, s.B.X) <= p.X && p.X <= max(s.A.X, s.B.X) &&
+		min(s.A.Y, s.B.Y) <= p.Y && p.Y <= max(s.A.Y, s.B.Y)
+}
+
+// IntersectSegments returns the points that s and t have in common, as the
+// segment between from and to, and true. If they have a single point in
+// common, from and to are the same point. If they have no point in common,
+// IntersectSegments returns false.
+//
+// Either segment may be a single point, where A and B are the same.
+func IntersectSegments(s, t Segment) (from, to RatPos2, ok bool) {
+	switch {
+	case s.A == s.B && t.A == t.B:
+		if s.A != t.A {
+			return RatPos2{}, RatPos2{}, false
+		}
+		return s.A.Rat(), s.A.Rat(), true
+	case s.A == s.B:
+		if !t.Contains(s.A) {
+			return RatPos2{}, RatPos2{}, false
+		}
+		return s.A.Rat(), s.A.Rat(), true
+	case t.A == t.B:
+		if !s.Contains(t.A) {
+			return RatPos2{}, RatPos2{}, false
+		}
+		return t.A.Rat(), t.A.Rat(), true
+	}
+	l := Line2{P: s.A, D: s.B.Sub(s.A)}
+	m := Line2{P: t.A, D: t.B.Sub(t.A)}
+	zero, one := rational.Int(0), rational.Int(1)
+	within := func(v rational.Rat) bool { return v.Cmp(zero) >= 0 && v.Cmp(one) <= 0 }
+	switch a, b, rel := IntersectLines(l, m); rel {
+	case Crossing:
+		if !within(a) || !within(b) {
+			return RatPos2{}, RatPos2{}, false
+		}
+		p := l.At(a)
+		return p, p, true
+	case Collinear:
+		// Find where the ends of t are along s, where 0 is s.A and 1 is s.B,
+		// and intersect that with [0, 1].
+		d := l.D.Rat()
+		dd := d.Dot(d)
+		lo := t.A.Rat().Sub(s.A.Rat()).Dot(d).Div(dd)
+		hi := t.B.Rat().Sub(s.A.Rat()).Dot(d).Div(dd)
+		if lo.Cmp(hi) > 0 {
+			lo, hi = hi, lo
+		}
+		if lo.Cmp(zero) < 0 {
+			lo = zero
+		}
+		if hi.Cmp(one) > 0 {
+			hi = one
+		}
+		if lo.Cmp(hi) > 0 {
+			return RatPos2{}, RatPos2{}, false
+		}
+		return l.At(lo), l.At(hi), true
+	default:
+		return RatPos2{}, RatPos2{}, false
+	}
+}
@@ -0,0 +1,295 @@
+package geometry
+
+import (
+	"testing"
+
+	"go.saser.se/adventofgo/math/rational"
+)
+
+func TestIntersectLines(t *testing.T) {
+	// The lines in the Huge case below cross where
+	//
+	//	300e12 - 1_000_000_007*t = 999_999_929*u
+	//	         999_999_937*t   = 1_000_000_009*u
+	hugeT := rational.Int(300_000_000_000_000).Mul(rational.Int(1_000_000_009)).Div(rational.Int(1_000_000_007*1_000_000_009 + 999_999_929*999_999_937))
+	for _, tt := range []struct {
+		name    string
+		l, m    Line2
+		wantRel LineRelation
+		wantT   rational.Rat
+		wantU   rational.Rat
+	}{
+		{
+			name:    "Perpendicular",
+			l:       Line2{P: Pos2{X: 0, Y: 0}, D: Pos2{X: 1, Y: 0}},
+			m:       Line2{P: Pos2{X: 3, Y: -2}, D: Pos2{X: 0, Y: 2}},
+			wantRel: Crossing,
+			wantT:   rational.Int(3),
+			wantU:   rational.Int(1),
+		},
+		{
+			name:    "Fraction",
+			l:       Line2{P: Pos2{X: 0, Y: 0}, D: Pos2{X: 2, Y: 1}},
+			m:       Line2{P: Pos2{X: 0, Y: 1}, D: Pos2{X: 3, Y: 0}},
+			wantRel: Crossing,
+			wantT:   rational.Int(1),
+			wantU:   rational.New(2, 3),
+		},
+		{
+			name:    "InThePast",
+			l:       Line2{P: Pos2{X: 0, Y: 0}, D: Pos2{X: 1, Y: 1}},
+			m:       Line2{P: Pos2{X: 1, Y: 6}, D: Pos2{X: 0, Y: 3}},
+			wantRel: Crossing,
+			wantT:   rational.Int(1),
+			wantU:   rational.New(-5, 3),
+		},
+		{
+			name:    "Parallel",
+			l:       Line2{P: Pos2{X: 0, Y: 0}, D: Pos2{X: 1, Y: 2}},
+			m:       Line2{P: Pos2{X: 1, Y: 0}, D: Pos2{X: -2, Y: -4}},
+			wantRel: Parallel,
+		},
+		{
+			name:    "Collinear",
+			l:       Line2{P: Pos2{X: 0, Y: 0}, D: Pos2{X: 1, Y: 2}},
+			m:       Line2{P: Pos2{X: 5, Y: 10}, D: Pos2{X: 2, Y: 4}},
+			wantRel: Collinear,
+		},
+		{
+			// Coordinates like those in 2023 day 24, where the cross products
+			// overflow an int64.
+			name:    "Huge",
+			l:       Line2{P: Pos2{X: 300_000_000_000_000, Y: 0}, D: Pos2{X: -1_000_000_007, Y: 999_999_937}},
+			m:       Line2{P: Pos2{X: 0, Y: 0}, D: Pos2{X: 999_999_929, Y: 1_000_000_009}},
+			wantRel: Crossing,
+			wantT:   hugeT,
+			wantU:   hugeT.Mul(rational.New(999_999_937, 1_000_000_009)),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			gotT, gotU, rel := IntersectLines(tt.l, tt.m)
+			if rel != tt.wantRel {
+				t.Fatalf("relation = %v; want %v", rel, tt.wantRel)
+			}
+			if rel != Crossing {
+				return
+			}
+			if p, q := tt.l.At(gotT), tt.m.At(gotU); !p.Equal(q) {
+				t.Errorf("l.At(%v) = %v and m.At(%v) = %v are not the same point", gotT, p, gotU, q)
+			}
+			if !gotT.Equal(tt.wantT) || !gotU.Equal(tt.wantU) {
+				t.Errorf("t, u = %v, %v; want %v, %v", gotT, gotU, tt.wantT, tt.wantU)
+			}
+		})
+	}
+}
+
+func TestIntersectLines_Hailstones(t *testing.T) {
+	// The example from 2023 day 24, where the paths of two pairs of
+	// hailstones cross inside the test area in the future.
+	stones := []Line3{
+		{P: Pos3{X: 19, Y: 13, Z: 30}, D: Pos3{X: -2, Y: 1, Z: -2}},
+		{P: Pos3{X: 18, Y: 19, Z: 22}, D: Pos3{X: -1, Y: -1, Z: -2}},
+		{P: Pos3{X: 20, Y: 25, Z: 34}, D: Pos3{X: -2, Y: -2, Z: -4}},
+		{P: Pos3{X: 12, Y: 31, Z: 28}, D: Pos3{X: -1, Y: -2, Z: -1}},
+		{P: Pos3{X: 20, Y: 19, Z: 15}, D: Pos3{X: 1, Y: -5, Z: -3}},
+	}
+	lo, hi := rational.Int(7), rational.Int(27)
+	inArea := func(v rational.Rat) bool { return v.Cmp(lo) >= 0 && v.Cmp(hi) <= 0 }
+	n := 0
+	for i, a := range stones {
+		for _, b := range stones[i+1:] {
+			l := Line2{P: Pos2{X: a.P.X, Y: a.P.Y}, D: Pos2{X: a.D.X, Y: a.D.Y}}
+			m := Line2{P: Pos2{X: b.P.X, Y: b.P.Y}, D: Pos2{X: b.D.X, Y: b.D.Y}}
+			t, u, rel := IntersectLines(l, m)
+			if rel != Crossing || t.Sign() < 0 || u.Sign() < 0 {
+				continue
+			}
+			if p := l.At(t); inArea(p.X) && inArea(p.Y) {
+				n++
+			}
+		}
+	}
+	if got, want := n, 2; got != want {
+		t.Errorf("crossings inside the test area: got %d; want %d", got, want)
+	}
+
+	// A rock thrown from 24, 13, 10 at velocity -3, 1, 2 hits every hailstone,
+	// at the given times.
+	rock := Line3{P: Pos3{X: 24, Y: 13, Z: 10}, D: Pos3{X: -3, Y: 1, Z: 2}}
+	for i, times := range []int64{5, 3, 4, 6, 1} {
+		t1, t2, rel := IntersectLines3(rock, stones[i])
+		if rel != Crossing {
+			t.Errorf("IntersectLines3(rock, %v) relation = %v; want %v", stones[i], rel, Crossing)
+			continue
+		}
+		if want := rational.Int(times); !t1.Equal(want) || !t2.Equal(want) {
+			t.Errorf("IntersectLines3(rock, %v) = %v, %v; want %v, %v", stones[i], t1, t2, want, want)
+		}
+	}
+}
+
+func TestIntersectLines3(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		l, m    Line3
+		wantRel LineRelation
+		wantT   rational.Rat
+		wantU   rational.Rat
+	}{
+		{
+			name:    "Crossing",
+			l:       Line3{P: Pos3{X: 0, Y: 0, Z: 0}, D: Pos3{X: 2, Y: 0, Z: 0}},
+			m:       Line3{P: Pos3{X: 1, Y: 1, Z: 1}, D: Pos3{X: 0, Y: 3, Z: 3}},
+			wantRel: Crossing,
+			wantT:   rational.New(1, 2),
+			wantU:   rational.New(-1, 3),
+		},
+		{
+			name:    "Skew",
+			l:       Line3{P: Pos3{X: 0, Y: 0, Z: 0}, D: Pos3{X: 1, Y: 0, Z: 0}},
+			m:       Line3{P: Pos3{X: 0, Y: 0, Z: 1}, D: Pos3{X: 0, Y: 1, Z: 0}},
+			wantRel: Skew,
+		},
+		{
+			name:    "Parallel",
+			l:       Line3{P: Pos3{X: 0, Y: 0, Z: 0}, D: Pos3{X: 1, Y: 1, Z: 1}},
+			m:       Line3{P: Pos3{X: 1, Y: 0, Z: 0}, D: Pos3{X: 2, Y: 2, Z: 2}},
+			wantRel: Parallel,
+		},
+		{
+			name:    "Collinear",
+			l:       Line3{P: Pos3{X: 0, Y: 0, Z: 0}, D: Pos3{X: 1, Y: 1, Z: 1}},
+			m:       Line3{P: Pos3{X: -4, Y: -4, Z: -4}, D: Pos3{X: -1, Y: -1, Z: -1}},
+			wantRel: Collinear,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			gotT, gotU, rel := IntersectLines3(tt.l, tt.m)
+			if rel != tt.wantRel {
+				t.Fatalf("relation = %v; want %v", rel, tt.wantRel)
+			}
+			if rel != Crossing {
+				return
+			}
+			if !gotT.Equal(tt.wantT) || !gotU.Equal(tt.wantU) {
+				t.Errorf("t, u = %v, %v; want %v, %v", gotT, gotU, tt.wantT, tt.wantU)
+			}
+			if p, q := tt.l.At(gotT), tt.m.At(gotU); !p.Equal(q) {
+				t.Errorf("l.At(%v) = %v and m.At(%v) = %v are not the same point", gotT, p, gotU, q)
+			}
+		})
+	}
+}
+
+func TestIntersectSegments(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		s, t     Segment
+		wantOK   bool
+		from, to RatPos2
+	}{
+		{
+			name:   "Crossing",
+			s:      Segment{A: Pos2{X: 0, Y: 0}, B: Pos2{X: 4, Y: 4}},
+			t:      Segment{A: Pos2{X: 0, Y: 4}, B: Pos2{X: 4, Y: 0}},
+			wantOK: true,
+			from:   Pos2{X: 2, Y: 2}.Rat(),
+			to:     Pos2{X: 2, Y: 2}.Rat(),
+		},
+		{
+			name:   "CrossingBetweenIntegers",
+			s:      Segment{A: Pos2{X: 0, Y: 0}, B: Pos2{X: 1, Y: 1}},
+			t:      Segment{A: Pos2{X: 0, Y: 1}, B: Pos2{X: 1, Y: 0}},
+			wantOK: true,
+			from:   RatPos2{X: rational.New(1, 2), Y: rational.New(1, 2)},
+			to:     RatPos2{X: rational.New(1, 2), Y: rational.New(1, 2)},
+		},
+		{
+			// Like the crossed wires in 2019 day 3.
+			name:   "Wires",
+			s:      Segment{A: Pos2{X: 6, Y: 7}, B: Pos2{X: 6, Y: 3}},
+			t:      Segment{A: Pos2{X: 3, Y: 5}, B: Pos2{X: 8, Y: 5}},
+			wantOK: true,
+			from:   Pos2{X: 6, Y: 5}.Rat(),
+			to:     Pos2{X: 6, Y: 5}.Rat(),
+		},
+		{
+			name:   "TouchingEnds",
+			s:      Segment{A: Pos2{X: 0, Y: 0}, B: Pos2{X: 2, Y: 0}},
+			t:      Segment{A: Pos2{X: 2, Y: 0}, B: Pos2{X: 2, Y: 5}},
+			wantOK: true,
+			from:   Pos2{X: 2, Y: 0}.Rat(),
+			to:     Pos2{X: 2, Y: 0}.Rat(),
+		},
+		{
+			name:   "Missing",
+			s:      Segment{A: Pos2{X: 0, Y: 0}, B: Pos2{X: 2, Y: 0}},
+			t:      Segment{A: Pos2{X: 3, Y: -1}, B: Pos2{X: 3, Y: 1}},
+			wantOK: false,
+		},
+		{
+			name:   "Parallel",
+			s:      Segment{A: Pos2{X: 0, Y: 0}, B: Pos2{X: 2, Y: 0}},
+			t:      Segment{A: Pos2{X: 0, Y: 1}, B: Pos2{X: 2, Y: 1}},
+			wantOK: false,
+		},
+		{
+			name:   "Overlapping",
+			s:      Segment{A: Pos2{X: 0, Y: 0}, B: Pos2{X: 4, Y: 2}},
+			t:      Segment{A: Pos2{X: 6, Y: 3}, B: Pos2{X: 2, Y: 1}},
+			wantOK: true,
+			from:   Pos2{X: 2, Y: 1}.Rat(),
+			to:     Pos2{X: 4, Y: 2}.Rat(),
+		},
+		{
+			name:   "CollinearTouching",
+			s:      Segment{A: Pos2{X: 0, Y: 0}, B: Pos2{X: 2, Y: 0}},
+			t:      Segment{A: Pos2{X: 2, Y: 0}, B: Pos2{X: 5, Y: 0}},
+			wantOK: true,
+			from:   Pos2{X: 2, Y: 0}.Rat(),
+			to:     Pos2{X: 2, Y: 0}.Rat(),
+		},
+		{
+			name:   "CollinearApart",
+			s:      Segment{A: Pos2{X: 0, Y: 0}, B: Pos2{X: 2, Y: 0}},
+			t:      Segment{A: Pos2{X: 3, Y: 0}, B: Pos2{X: 5, Y: 0}},
+			wantOK: false,
+		},
+		{
+			name:   "PointOnSegment",
+			s:      Segment{A: Pos2{X: 1, Y: 1}, B: Pos2{X: 1, Y: 1}},
+			t:      Segment{A: Pos2{X: 0, Y: 0}, B: Pos2{X: 3, Y: 3}},
+			wantOK: true,
+			from:   Pos2{X: 1, Y: 1}.Rat(),
+			to:     Pos2{X: 1, Y: 1}.Rat(),
+		},
+		{
+			name:   "PointOffSegment",
+			s:      Segment{A: Pos2{X: 0, Y: 0}, B: Pos2{X: 3, Y: 3}},
+			t:      Segment{A: Pos2{X: 4, Y: 4}, B: Pos2{X: 4, Y: 4}},
+			wantOK: false,
+		},
+		{
+			name:   "SamePoint",
+			s:      Segment{A: Pos2{X: 4, Y: 4}, B: Pos2{X: 4, Y: 4}},
+			t:      Segment{A: Pos2{X: 4, Y: 4}, B: Pos2{X: 4, Y: 4}},
+			wantOK: true,
+			from:   Pos2{X: 4, Y: 4}.Rat(),
+			to:     Pos2{X: 4, Y: 4}.Rat(),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, ok := IntersectSegments(tt.s, tt.t)
+			if ok != tt.wantOK {
+				t.Fatalf("IntersectSegments(%v, %v) ok = %v; want %v", tt.s, tt.t, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if !from.Equal(tt.from) || !to.Equal(tt.to) {
+				t.Errorf("IntersectSegments(%v, %v) = %v, %v; want %v, %v", tt.s, tt.t, from, to, tt.from, tt.to)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package geometry
+
+import "strconv"
+
+// Point is a point with integer coordinates, like Pos2 or asciigrid.Pos. The
+// polygon functions in this package take a slice of any Point type, so that
+// loops found in a grid can be used directly.
+type Point interface {
+	XY() (x, y int)
+}
+
+// vertex returns the i-th vertex of poly as a Pos2, wrapping around at the end.
+func vertex[P Point](poly []P, i int) Pos2 {
+	x, y := poly[i%len(poly)].XY()
+	return Pos2{X: x, Y: y}
+}
+
+// DoubleArea returns twice the signed area of the polygon with the given
+// vertices, using the shoelace formula. The polygon is closed, so the last
+// vertex connects back to the first, and it must not cross itself. The result
+// is positive if the vertices go around counter-clockwise with Y pointing up.
+//
+// The area is doubled so that it's an integer even for polygons like
+// triangles, whose areas can end in a half.
+func DoubleArea[P Point](poly []P) int {
+	area := 0
+	for i := range poly {
+		area += vertex(poly, i).Cross(vertex(poly, i+1))
+	}
+	return area
+}
+
+// Area returns the area of the polygon with the given vertices, rounded down.
+// It's exact for polygons whose edges are all horizontal or vertical, like the
+// ones made by walking around a grid.
+func Area[P Point](poly []P) int {
+	return abs(DoubleArea(poly)) / 2
+}
+
+// Winding is the direction in which the vertices of a polygon go around it.
+type Winding int
+
+const (
+	// Degenerate is the winding of polygons with no area.
+	Degenerate Winding = 0
+	// CounterClockwise is the winding of polygons whose vertices go around
+	// counter-clockwise when Y points up.
+	CounterClockwise Winding = 1
+	// Clockwise is the winding of polygons whose vertices go around clockwise
+	// when Y points up.
+	Clockwise Winding = -1
+)
+
+// WindingOf returns the winding of the polygon with the given vertices.
+//
+// With Y pointing down, like the rows of an asciigrid, everything is mirrored:
+// a loop that goes around clockwise on screen has a CounterClockwise winding.
+func WindingOf[P Point](poly []P) Winding {
+	switch area := DoubleArea(poly); {
+	case area > 0:
+		return CounterClockwise
+	case area < 0:
+		return Clockwise
+	default:
+		return Degenerate
+	}
+}
+
+// BoundaryPoints returns the number of integer points on the edges of the
+// polygon with the given vertices.
+func BoundaryPoints[P Point](poly []P) int {
+	n := 0
+	for i := range poly {
+		d := vertex(poly, i+1).Sub(vertex(poly, i))
+		n += gcd(d.X, d.Y)
+	}
+	return n
+}
+
+// InteriorPoints returns the number of integer points strictly inside the
+// polygon with the given vertices, using Pick's theorem:
+//
+//	area = interior + boundary/2 - 1
+func InteriorPoints[P Point](poly []P) int {
+	return (abs(DoubleArea(poly)) - BoundaryPoints(poly) + 2) / 2
+}
+
+// LatticePoints returns the number of integer points inside or on the edges of
+// the polygon with the given vertices. For a loop through the centers of grid
+// cells, this is the number of cells the loop goes through or encloses.
+func LatticePoints[P Point](poly []P) int {
+	return InteriorPoints(poly) + BoundaryPoints(poly)
+}
+
+// Location is where a point is relative to a polygon.
+type Location int
+
+const (
+	// Outside is the location of points that are not in the polygon.
+	Outside Location = iota
+	// OnBoundary is the location of points on an edge of the polygon,
+	// including its vertices.
+	OnBoundary
+	// Inside is the location of points strictly inside the polygon.
+	Inside
+)
+
+func (l Location) String() string {
+	switch l {
+	case Outside:
+		return "Outside"
+	case OnBoundary:
+		return "OnBoundary"
+	case Inside:
+		return "Inside"
+	default:
+		return "Location(" + strconv.Itoa(int(l)) + ")"
+	}
+}
+
+// Locate returns whether p is inside, outside or on an edge of the polygon
+// with the given vertices. It only uses integer arithmetic, so points on edges
+// are always found, and it works for polygons that aren't convex.
+func Locate[P Point](poly []P, p P) Location {
+	x, y := p.XY()
+	q := Pos2{X: x, Y: y}
+	inside := false
+	for i := range poly {
+		a, b := vertex(poly, i), vertex(poly, i+1)
+		cross := b.Sub(a).Cross(q.Sub(a))
+		if cross == 0 && min(a.X, b.X) <= q.X && q.X <= max(a.X, b.X) && min(a.Y, b.Y) <= q.Y && q.Y <= max(a.Y, b.Y) {
+			return OnBoundary
+		}
+		// Cast a ray from q towards positive X and count the edges it crosses,
+		// which are the ones that q is to the left of. Each edge includes its
+		// lower end but not its upper end, so that a ray through a vertex
+		// counts once if the polygon crosses the ray there and not at all if
+		// it only touches it, and horizontal edges never count.
+		switch {
+		case a.Y <= q.Y && q.Y < b.Y && cross > 0:
+			// An upward edge.
+			inside = !inside
+		case b.Y <= q.Y && q.Y < a.Y && cross < 0:
+			// A downward edge.
+			inside = !inside
+		}
+	}
+	if inside {
+		return Inside
+	}
+	return Outside
+}
@@ -0,0 +1,248 @@
+package geometry
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestArea(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		poly     []Pos2
+		area     int
+		winding  Winding
+		boundary int
+		interior int
+	}{
+		{
+			name:     "Square",
+			poly:     []Pos2{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 4}},
+			area:     16,
+			winding:  CounterClockwise,
+			boundary: 16,
+			interior: 9,
+		},
+		{
+			name:     "SquareClockwise",
+			poly:     []Pos2{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 4, Y: 4}, {X: 4, Y: 0}},
+			area:     16,
+			winding:  Clockwise,
+			boundary: 16,
+			interior: 9,
+		},
+		{
+			// An L shape made of three unit squares.
+			name:     "L",
+			poly:     []Pos2{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 2}, {X: 0, Y: 2}},
+			area:     3,
+			winding:  CounterClockwise,
+			boundary: 8,
+			interior: 0,
+		},
+		{
+			name:     "Triangle",
+			poly:     []Pos2{{X: 0, Y: 0}, {X: 3, Y: 0}, {X: 0, Y: 3}},
+			area:     4, // 4.5 rounded down
+			winding:  CounterClockwise,
+			boundary: 9,
+			interior: 1,
+		},
+		{
+			name:     "Line",
+			poly:     []Pos2{{X: 0, Y: 0}, {X: 3, Y: 3}},
+			area:     0,
+			winding:  Degenerate,
+			boundary: 6,
+			interior: 0,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Area(tt.poly); got != tt.area {
+				t.Errorf("Area() = %d; want %d", got, tt.area)
+			}
+			if got := WindingOf(tt.poly); got != tt.winding {
+				t.Errorf("WindingOf() = %v; want %v", got, tt.winding)
+			}
+			if got := BoundaryPoints(tt.poly); got != tt.boundary {
+				t.Errorf("BoundaryPoints() = %d; want %d", got, tt.boundary)
+			}
+			if tt.winding == Degenerate {
+				return
+			}
+			if got := InteriorPoints(tt.poly); got != tt.interior {
+				t.Errorf("InteriorPoints() = %d; want %d", got, tt.interior)
+			}
+		})
+	}
+}
+
+// step is a direction in "UDLR" and a distance.
+type step struct {
+	dir byte
+	n   int
+}
+
+// walk returns the corners of the loop made by following the steps, with Y
+// pointing down.
+func walk(steps []step) []Pos2 {
+	var (
+		p    Pos2
+		poly []Pos2
+	)
+	for _, s := range steps {
+		switch s.dir {
+		case 'U':
+			p.Y -= s.n
+		case 'D':
+			p.Y += s.n
+		case 'L':
+			p.X -= s.n
+		case 'R':
+			p.X += s.n
+		}
+		poly = append(poly, p)
+	}
+	return poly
+}
+
+func TestLatticePoints_Lagoon(t *testing.T) {
+	// The example from 2023 day 18, where the lagoon holds 62 cubic meters.
+	steps := []step{
+		{'R', 6}, {'D', 5}, {'L', 2}, {'D', 2}, {'R', 2}, {'D', 2}, {'L', 5},
+		{'U', 2}, {'L', 1}, {'U', 2}, {'R', 2}, {'U', 3}, {'L', 2}, {'U', 2},
+	}
+	poly := walk(steps)
+	if got, want := LatticePoints(poly), 62; got != want {
+		t.Errorf("LatticePoints() = %d; want %d", got, want)
+	}
+	// The loop goes clockwise on screen, where Y points down.
+	if got, want := WindingOf(poly), CounterClockwise; got != want {
+		t.Errorf("WindingOf() = %v; want %v", got, want)
+	}
+}
+
+func TestInteriorPoints_EnclosedTiles(t *testing.T) {
+	// The loop from one of the examples in 2023 day 10, where 4 tiles are
+	// enclosed. Every tile of the loop is a vertex, collinear or not.
+	grid := []string{
+		"...........",
+		".S-------7.",
+		".|F-----7|.",
+		".||.....||.",
+		".||.....||.",
+		".|L-7.F-J|.",
+		".|..|.|..|.",
+		".L--J.L--J.",
+		"...........",
+	}
+	// Walk the loop from S, which is a F-shaped corner.
+	var (
+		poly       []Pos2
+		p          = Pos2{X: 1, Y: 1}
+		dx, dy     = 1, 0
+		startFound = false
+	)
+	for !startFound {
+		poly = append(poly, p)
+		p = Pos2{X: p.X + dx, Y: p.Y + dy}
+		switch grid[p.Y][p.X] {
+		case 'S':
+			startFound = true
+		case 'L', '7':
+			dx, dy = dy, dx
+		case 'J', 'F':
+			dx, dy = -dy, -dx
+		}
+	}
+	if got, want := InteriorPoints(poly), 4; got != want {
+		t.Errorf("InteriorPoints() = %d; want %d", got, want)
+	}
+	for y, row := range grid {
+		for x := range row {
+			p := Pos2{X: x, Y: y}
+			got := Locate(poly, p)
+			want := Outside
+			switch {
+			case row[x] != '.':
+				want = OnBoundary
+			case y == 6 && (x == 2 || x == 3 || x == 7 || x == 8):
+				want = Inside
+			}
+			if got != want {
+				t.Errorf("Locate(%v) = %v; want %v", p, got, want)
+			}
+		}
+	}
+}
+
+func TestLocate(t *testing.T) {
+	// A U shape, so that rays pass through vertices and along horizontal
+	// edges:
+	//
+	//	X.X.X
+	//	X.XXX
+	//	XXX..
+	poly := []Pos2{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 2}, {X: 0, Y: 2}}
+	for _, tt := range []struct {
+		p    Pos2
+		want Location
+	}{
+		{p: Pos2{X: 1, Y: 1}, want: Inside},
+		{p: Pos2{X: 0, Y: 1}, want: OnBoundary},
+		{p: Pos2{X: 3, Y: 0}, want: OnBoundary},
+		{p: Pos2{X: 3, Y: 1}, want: OnBoundary},
+		{p: Pos2{X: 2, Y: 2}, want: OnBoundary},
+		{p: Pos2{X: -1, Y: 0}, want: Outside},
+		{p: Pos2{X: -1, Y: 1}, want: Outside},
+		{p: Pos2{X: -1, Y: 2}, want: Outside},
+		{p: Pos2{X: 3, Y: 2}, want: Outside},
+		{p: Pos2{X: 5, Y: 1}, want: Outside},
+	} {
+		if got := Locate(poly, tt.p); got != tt.want {
+			t.Errorf("Locate(%v) = %v; want %v", tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestTriangles_Random(t *testing.T) {
+	// Check Locate and Pick's theorem against a brute force count for
+	// triangles, where a point is inside if it's on the same side of all three
+	// edges.
+	r := rand.New(rand.NewPCG(1, 2))
+	corner := func() Pos2 { return Pos2{X: r.IntN(20) - 10, Y: r.IntN(20) - 10} }
+	for range 300 {
+		tri := []Pos2{corner(), corner(), corner()}
+		if WindingOf(tri) == Degenerate {
+			continue
+		}
+		var interior, boundary int
+		for y := -10; y < 10; y++ {
+			for x := -10; x < 10; x++ {
+				p := Pos2{X: x, Y: y}
+				var signs [3]int
+				for i := range tri {
+					a, b := tri[i], tri[(i+1)%3]
+					signs[i] = b.Sub(a).Cross(p.Sub(a))
+				}
+				want := Outside
+				switch {
+				case signs[0] > 0 && signs[1] > 0 && signs[2] > 0, signs[0] < 0 && signs[1] < 0 && signs[2] < 0:
+					want = Inside
+					interior++
+				case signs[0] >= 0 && signs[1] >= 0 && signs[2] >= 0, signs[0] <= 0 && signs[1] <= 0 && signs[2] <= 0:
+					want = OnBoundary
+					boundary++
+				}
+				if got := Locate(tri, p); got != want {
+					t.Fatalf("Locate(%v, %v) = %v; want %v", tri, p, got, want)
+				}
+			}
+		}
+		if got := InteriorPoints(tri); got != interior {
+			t.Errorf("InteriorPoints(%v) = %d; want %d", tri, got, interior)
+		}
+		if got := BoundaryPoints(tri); got != boundary {
+			t.Errorf("BoundaryPoints(%v) = %d; want %d", tri, got, boundary)
+		}
+	}
+}
@@ -1,5 +1,11 @@
 package geometry
 
+import (
+	"iter"
+
+	"go.saser.se/adventofgo/math/rational"
+)
+
 // abs returns the absolute value of x.
 func abs(x int) int {
 	if x >= 0 {
@@ -8,6 +14,15 @@ func abs(x int) int {
 	return -x
 }
 
+// gcd returns the greatest common divisor of |a| and |b|, or 0 if both are 0.
+func gcd(a, b int) int {
+	a, b = abs(a), abs(b)
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
 // Pos2 represents a point in 2D space with integer coordinates.
 type Pos2 struct {
 	X, Y int
@@ -29,8 +44,123 @@ func (a Pos2) Sub(b Pos2) Pos2 {
 	}
 }
 
+// Dot returns the dot product of a and b.
+func (a Pos2) Dot(b Pos2) int {
+	return a.X*b.X + a.Y*b.Y
+}
+
+// Cross returns the z component of the cross product of a and b, i.e.
+// a.X*b.Y - a.Y*b.X. It is positive if b is less than half a turn from a in
+// the direction of increasing angle (counterclockwise when Y points up),
+// negative if it's less than half a turn in the other direction, and zero if a
+// and b are parallel.
+func (a Pos2) Cross(b Pos2) int {
+	return a.X*b.Y - a.Y*b.X
+}
+
 // L1Norm returns |p.X| + |p.Y|. This is also known as the Manhattan distance to
 // the origin.
 func (p Pos2) L1Norm() int {
 	return abs(p.X) + abs(p.Y)
 }
+
+// Scale returns p with every coordinate multiplied by k.
+func (p Pos2) Scale(k int) Pos2 {
+	return Pos2{
+		X: p.X * k,
+		Y: p.Y * k,
+	}
+}
+
+// LInfNorm returns max(|p.X|, |p.Y|). This is also known as the Chebyshev
+// distance to the origin, or the number of king moves needed to reach it.
+func (p Pos2) LInfNorm() int {
+	return max(abs(p.X), abs(p.Y))
+}
+
+// Min returns the component-wise minimum of a and b.
+func (a Pos2) Min(b Pos2) Pos2 {
+	return Pos2{
+		X: min(a.X, b.X),
+		Y: min(a.Y, b.Y),
+	}
+}
+
+// Max returns the component-wise maximum of a and b.
+func (a Pos2) Max(b Pos2) Pos2 {
+	return Pos2{
+		X: max(a.X, b.X),
+		Y: max(a.Y, b.Y),
+	}
+}
+
+// Neighbors4 iterates over the 4 positions that differ from p by 1 in exactly
+// one coordinate.
+func (p Pos2) Neighbors4() iter.Seq[Pos2] {
+	return func(yield func(Pos2) bool) {
+		for _, d := range [...]Pos2{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+			if !yield(p.Add(d)) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors8 iterates over the 8 positions that differ from p by at most 1 in
+// every coordinate, in lexicographic order.
+func (p Pos2) Neighbors8() iter.Seq[Pos2] {
+	return func(yield func(Pos2) bool) {
+		for dx := -1; dx <= 1; dx++ {
+			for dy := -1; dy <= 1; dy++ {
+				if (dx != 0 || dy != 0) && !yield(Pos2{X: p.X + dx, Y: p.Y + dy}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Reduce returns the shortest vector with integer coordinates pointing in the
+// same direction as p, by dividing its coordinates by their greatest common
+// divisor. Two vectors point in the same direction exactly when their reduced
+// forms are equal. The zero vector reduces to itself.
+func (p Pos2) Reduce() Pos2 {
+	d := gcd(p.X, p.Y)
+	if d == 0 {
+		return p
+	}
+	return Pos2{X: p.X / d, Y: p.Y / d}
+}
+
+// Rotate45 rotates p 45 degrees counter-clockwise around the origin and scales
+// it up by √2, which keeps its coordinates integers:
+//
+//	Rotate45({X, Y}) = {X - Y, X + Y}
+//
+// The Manhattan distance between two points is the same as the Chebyshev
+// distance between their rotations, so diamonds become axis-aligned squares.
+// Problems about overlapping diamonds can then be solved with Rect. The
+// rotation of a point always has an even X + Y, so only points with an even X
+// + Y can be rotated back by Unrotate45.
+func (p Pos2) Rotate45() Pos2 {
+	return Pos2{X: p.X - p.Y, Y: p.X + p.Y}
+}
+
+// Unrotate45 is the inverse of Rotate45. If p is not the rotation of any point
+// with integer coordinates, Unrotate45 returns false.
+func (p Pos2) Unrotate45() (Pos2, bool) {
+	if (p.X+p.Y)%2 != 0 {
+		return Pos2{}, false
+	}
+	return Pos2{X: (p.X + p.Y) / 2, Y: (p.Y - p.X) / 2}, true
+}
+
+// XY returns p.X and p.Y, which makes Pos2 a Point.
+func (p Pos2) XY() (x, y int) {
+	return p.X, p.Y
+}
+
+// Rat returns p with rational coordinates.
+func (p Pos2) Rat() RatPos2 {
+	return RatPos2{X: rational.Int(int64(p.X)), Y: rational.Int(int64(p.Y))}
+}
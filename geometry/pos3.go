@@ -0,0 +1,117 @@
+package geometry
+
+import (
+	"iter"
+
+	"go.saser.se/adventofgo/math/rational"
+)
+
+// Pos3 represents a point in 3D space with integer coordinates.
+type Pos3 struct {
+	X, Y, Z int
+}
+
+// Add returns a + b.
+func (a Pos3) Add(b Pos3) Pos3 {
+	return Pos3{
+		X: a.X + b.X,
+		Y: a.Y + b.Y,
+		Z: a.Z + b.Z,
+	}
+}
+
+// Sub returns a - b.
+func (a Pos3) Sub(b Pos3) Pos3 {
+	return Pos3{
+		X: a.X - b.X,
+		Y: a.Y - b.Y,
+		Z: a.Z - b.Z,
+	}
+}
+
+// Scale returns p with every coordinate multiplied by k.
+func (p Pos3) Scale(k int) Pos3 {
+	return Pos3{
+		X: p.X * k,
+		Y: p.Y * k,
+		Z: p.Z * k,
+	}
+}
+
+// Dot returns the dot product of a and b.
+func (a Pos3) Dot(b Pos3) int {
+	return a.X*b.X + a.Y*b.Y + a.Z*b.Z
+}
+
+// Cross returns the cross product of a and b, which is perpendicular to both
+// of them and zero if they are parallel.
+func (a Pos3) Cross(b Pos3) Pos3 {
+	return Pos3{
+		X: a.Y*b.Z - a.Z*b.Y,
+		Y: a.Z*b.X - a.X*b.Z,
+		Z: a.X*b.Y - a.Y*b.X,
+	}
+}
+
+// L1Norm returns |p.X| + |p.Y| + |p.Z|. This is also known as the Manhattan
+// distance to the origin.
+func (p Pos3) L1Norm() int {
+	return abs(p.X) + abs(p.Y) + abs(p.Z)
+}
+
+// LInfNorm returns max(|p.X|, |p.Y|, |p.Z|). This is also known as the
+// Chebyshev distance to the origin.
+func (p Pos3) LInfNorm() int {
+	return max(abs(p.X), abs(p.Y), abs(p.Z))
+}
+
+// Min returns the component-wise minimum of a and b.
+func (a Pos3) Min(b Pos3) Pos3 {
+	return Pos3{
+		X: min(a.X, b.X),
+		Y: min(a.Y, b.Y),
+		Z: min(a.Z, b.Z),
+	}
+}
+
+// Max returns the component-wise maximum of a and b.
+func (a Pos3) Max(b Pos3) Pos3 {
+	return Pos3{
+		X: max(a.X, b.X),
+		Y: max(a.Y, b.Y),
+		Z: max(a.Z, b.Z),
+	}
+}
+
+// Neighbors6 iterates over the 6 positions that differ from p by 1 in exactly
+// one coordinate, i.e. the cubes sharing a face with p.
+func (p Pos3) Neighbors6() iter.Seq[Pos3] {
+	return func(yield func(Pos3) bool) {
+		for _, d := range [...]Pos3{{-1, 0, 0}, {1, 0, 0}, {0, -1, 0}, {0, 1, 0}, {0, 0, -1}, {0, 0, 1}} {
+			if !yield(p.Add(d)) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors26 iterates over the 26 positions that differ from p by at most 1
+// in every coordinate, in lexicographic order.
+func (p Pos3) Neighbors26() iter.Seq[Pos3] {
+	return func(yield func(Pos3) bool) {
+		for dx := -1; dx <= 1; dx++ {
+			for dy := -1; dy <= 1; dy++ {
+				for dz := -1; dz <= 1; dz++ {
+					if (dx != 0 || dy != 0 || dz != 0) && !yield(Pos3{X: p.X + dx, Y: p.Y + dy, Z: p.Z + dz}) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// Rat returns p with rational coordinates.
+func (p Pos3) Rat() RatPos3 {
+	return RatPos3{X: rational.Int(int64(p.X)), Y: rational.Int(int64(p.Y)), Z: rational.Int(int64(p.Z))}
+}
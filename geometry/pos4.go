@@ -0,0 +1,110 @@
+package geometry
+
+import "iter"
+
+// Pos4 represents a point in 4D space with integer coordinates.
+type Pos4 struct {
+	X, Y, Z, W int
+}
+
+// Add returns a + b.
+func (a Pos4) Add(b Pos4) Pos4 {
+	return Pos4{
+		X: a.X + b.X,
+		Y: a.Y + b.Y,
+		Z: a.Z + b.Z,
+		W: a.W + b.W,
+	}
+}
+
+// Sub returns a - b.
+func (a Pos4) Sub(b Pos4) Pos4 {
+	return Pos4{
+		X: a.X - b.X,
+		Y: a.Y - b.Y,
+		Z: a.Z - b.Z,
+		W: a.W - b.W,
+	}
+}
+
+// Scale returns p with every coordinate multiplied by k.
+func (p Pos4) Scale(k int) Pos4 {
+	return Pos4{
+		X: p.X * k,
+		Y: p.Y * k,
+		Z: p.Z * k,
+		W: p.W * k,
+	}
+}
+
+// Dot returns the dot product of a and b. There is no cross product in 4D.
+func (a Pos4) Dot(b Pos4) int {
+	return a.X*b.X + a.Y*b.Y + a.Z*b.Z + a.W*b.W
+}
+
+// L1Norm returns |p.X| + |p.Y| + |p.Z| + |p.W|. This is also known as the
+// Manhattan distance to the origin.
+func (p Pos4) L1Norm() int {
+	return abs(p.X) + abs(p.Y) + abs(p.Z) + abs(p.W)
+}
+
+// LInfNorm returns max(|p.X|, |p.Y|, |p.Z|, |p.W|). This is also known as the
+// Chebyshev distance to the origin.
+func (p Pos4) LInfNorm() int {
+	return max(abs(p.X), abs(p.Y), abs(p.Z), abs(p.W))
+}
+
+// Min returns the component-wise minimum of a and b.
+func (a Pos4) Min(b Pos4) Pos4 {
+	return Pos4{
+		X: min(a.X, b.X),
+		Y: min(a.Y, b.Y),
+		Z: min(a.Z, b.Z),
+		W: min(a.W, b.W),
+	}
+}
+
+// Max returns the component-wise maximum of a and b.
+func (a Pos4) Max(b Pos4) Pos4 {
+	return Pos4{
+		X: max(a.X, b.X),
+		Y: max(a.Y, b.Y),
+		Z: max(a.Z, b.Z),
+		W: max(a.W, b.W),
+	}
+}
+
+// Neighbors8 iterates over the 8 positions that differ from p by 1 in exactly
+// one coordinate.
+func (p Pos4) Neighbors8() iter.Seq[Pos4] {
+	return func(yield func(Pos4) bool) {
+		for _, d := range [...]Pos4{
+			{-1, 0, 0, 0}, {1, 0, 0, 0},
+			{0, -1, 0, 0}, {0, 1, 0, 0},
+			{0, 0, -1, 0}, {0, 0, 1, 0},
+			{0, 0, 0, -1}, {0, 0, 0, 1},
+		} {
+			if !yield(p.Add(d)) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors80 iterates over the 80 positions that differ from p by at most 1
+// in every coordinate, in lexicographic order.
+func (p Pos4) Neighbors80() iter.Seq[Pos4] {
+	return func(yield func(Pos4) bool) {
+		for dx := -1; dx <= 1; dx++ {
+			for dy := -1; dy <= 1; dy++ {
+				for dz := -1; dz <= 1; dz++ {
+					for dw := -1; dw <= 1; dw++ {
+						if (dx != 0 || dy != 0 || dz != 0 || dw != 0) && !yield(Pos4{X: p.X + dx, Y: p.Y + dy, Z: p.Z + dz, W: p.W + dw}) {
+							return
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package geometry
+
+import (
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// checkNeighbors checks that the neighbors of p are n distinct positions with
+// the given norm.
+func checkNeighbors[P comparable](t *testing.T, name string, p P, neighbors iter.Seq[P], n int, norm func(P) int, wantNorm int) {
+	t.Helper()
+	seen := make(map[P]bool)
+	for q := range neighbors {
+		if seen[q] {
+			t.Errorf("%s: %v appears twice", name, q)
+		}
+		seen[q] = true
+		if got := norm(q); got != wantNorm {
+			t.Errorf("%s: %v is %d away from %v; want %d", name, q, got, p, wantNorm)
+		}
+	}
+	if len(seen) != n {
+		t.Errorf("%s: got %d neighbors; want %d", name, len(seen), n)
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	p2 := Pos2{X: 3, Y: -7}
+	checkNeighbors(t, "Pos2.Neighbors4", p2, p2.Neighbors4(), 4, func(q Pos2) int { return q.Sub(p2).L1Norm() }, 1)
+	checkNeighbors(t, "Pos2.Neighbors8", p2, p2.Neighbors8(), 8, func(q Pos2) int { return q.Sub(p2).LInfNorm() }, 1)
+	p3 := Pos3{X: 3, Y: -7, Z: 11}
+	checkNeighbors(t, "Pos3.Neighbors6", p3, p3.Neighbors6(), 6, func(q Pos3) int { return q.Sub(p3).L1Norm() }, 1)
+	checkNeighbors(t, "Pos3.Neighbors26", p3, p3.Neighbors26(), 26, func(q Pos3) int { return q.Sub(p3).LInfNorm() }, 1)
+	p4 := Pos4{X: 3, Y: -7, Z: 11, W: 0}
+	checkNeighbors(t, "Pos4.Neighbors8", p4, p4.Neighbors8(), 8, func(q Pos4) int { return q.Sub(p4).L1Norm() }, 1)
+	checkNeighbors(t, "Pos4.Neighbors80", p4, p4.Neighbors80(), 80, func(q Pos4) int { return q.Sub(p4).LInfNorm() }, 1)
+}
+
+func TestPos3_Cross(t *testing.T) {
+	x, y, z := Pos3{X: 1}, Pos3{Y: 1}, Pos3{Z: 1}
+	if got := x.Cross(y); got != z {
+		t.Errorf("x.Cross(y) = %v; want %v", got, z)
+	}
+	r := rand.New(rand.NewPCG(1, 2))
+	random := func() Pos3 { return Pos3{X: r.IntN(21) - 10, Y: r.IntN(21) - 10, Z: r.IntN(21) - 10} }
+	for range 1000 {
+		a, b := random(), random()
+		c := a.Cross(b)
+		if a.Dot(c) != 0 || b.Dot(c) != 0 {
+			t.Fatalf("%v.Cross(%v) = %v is not perpendicular to both", a, b, c)
+		}
+		if got, want := b.Cross(a), c.Scale(-1); got != want {
+			t.Fatalf("%v.Cross(%v) = %v; want %v", b, a, got, want)
+		}
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	a, b := Pos4{X: 1, Y: 5, Z: -2, W: 0}, Pos4{X: 3, Y: -5, Z: -2, W: 9}
+	if got, want := a.Min(b), (Pos4{X: 1, Y: -5, Z: -2, W: 0}); got != want {
+		t.Errorf("%v.Min(%v) = %v; want %v", a, b, got, want)
+	}
+	if got, want := a.Max(b), (Pos4{X: 3, Y: 5, Z: -2, W: 9}); got != want {
+		t.Errorf("%v.Max(%v) = %v; want %v", a, b, got, want)
+	}
+	p, q := Pos2{X: -1, Y: 4}, Pos2{X: 2, Y: 3}
+	if got, want := p.Min(q), (Pos2{X: -1, Y: 3}); got != want {
+		t.Errorf("%v.Min(%v) = %v; want %v", p, q, got, want)
+	}
+	if got, want := p.Max(q).Sub(p.Min(q)).LInfNorm(), 3; got != want {
+		t.Errorf("size of the bounding box of %v and %v = %d; want %d", p, q, got, want)
+	}
+}
+
+func TestSurfaceArea(t *testing.T) {
+	// The example from Advent of Code 2022 day 18, where the surface area of a
+	// droplet is the number of faces not shared with another cube.
+	cubes := []Pos3{
+		{2, 2, 2}, {1, 2, 2}, {3, 2, 2}, {2, 1, 2}, {2, 3, 2}, {2, 2, 1}, {2, 2, 3},
+		{2, 2, 4}, {2, 2, 6}, {1, 2, 5}, {3, 2, 5}, {2, 1, 5}, {2, 3, 5},
+	}
+	area := 0
+	for _, c := range cubes {
+		for n := range c.Neighbors6() {
+			if !slices.Contains(cubes, n) {
+				area++
+			}
+		}
+	}
+	if got, want := area, 64; got != want {
+		t.Errorf("surface area = %d; want %d", got, want)
+	}
+}
+
+// life runs the rules from Advent of Code 2020 day 17 for the given number of
+// cycles, and returns the number of active cubes at the end.
+func life[P comparable](active map[P]bool, neighbors func(P) iter.Seq[P], cycles int) int {
+	for range cycles {
+		counts := make(map[P]int)
+		for p := range active {
+			for n := range neighbors(p) {
+				counts[n]++
+			}
+		}
+		next := make(map[P]bool)
+		for p, n := range counts {
+			if n == 3 || n == 2 && active[p] {
+				next[p] = true
+			}
+		}
+		active = next
+	}
+	return len(active)
+}
+
+func TestConwayCubes(t *testing.T) {
+	// The example from Advent of Code 2020 day 17.
+	initial := []Pos2{{1, 0}, {2, 1}, {0, 2}, {1, 2}, {2, 2}}
+	active3 := make(map[Pos3]bool)
+	active4 := make(map[Pos4]bool)
+	for _, p := range initial {
+		active3[Pos3{X: p.X, Y: p.Y}] = true
+		active4[Pos4{X: p.X, Y: p.Y}] = true
+	}
+	if got, want := life(active3, Pos3.Neighbors26, 6), 112; got != want {
+		t.Errorf("active cubes in 3D = %d; want %d", got, want)
+	}
+	if got, want := life(active4, Pos4.Neighbors80, 6), 848; got != want {
+		t.Errorf("active cubes in 4D = %d; want %d", got, want)
+	}
+}
@@ -0,0 +1,252 @@
+// Package hexgrid provides coordinates on a grid of hexagons, for puzzles where
+// every position has six neighbors.
+//
+// Positions are given in axial coordinates (Q, R), where the third cube
+// coordinate S = -Q-R is implied. The coordinates don't depend on which way the
+// hexagons are turned, but the names of the directions do: in a PointyTop
+// layout the neighbors are e, se, sw, w, nw and ne, and in a FlatTop layout
+// they are n, ne, se, s, sw and nw.
+package hexgrid
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+
+	"go.saser.se/adventofgo/container/set"
+	"go.saser.se/adventofgo/geometry"
+)
+
+// Hex is a hexagon in axial coordinates. It doubles as the step between two
+// hexagons, such as a direction.
+type Hex struct {
+	Q, R int
+}
+
+// FromCube returns the hexagon with the cube coordinates q, r and s, which must
+// add up to 0.
+func FromCube(q, r, s int) (Hex, error) {
+	if q+r+s != 0 {
+		return Hex{}, fmt.Errorf("hexgrid: cube coordinates (%d, %d, %d) don't add up to 0", q, r, s)
+	}
+	return Hex{Q: q, R: r}, nil
+}
+
+// S returns the third cube coordinate of h, -h.Q-h.R.
+func (h Hex) S() int {
+	return -h.Q - h.R
+}
+
+// Add returns a + b.
+func (a Hex) Add(b Hex) Hex {
+	return Hex{Q: a.Q + b.Q, R: a.R + b.R}
+}
+
+// Sub returns a - b.
+func (a Hex) Sub(b Hex) Hex {
+	return Hex{Q: a.Q - b.Q, R: a.R - b.R}
+}
+
+// Scale returns h taken k times.
+func (h Hex) Scale(k int) Hex {
+	return Hex{Q: h.Q * k, R: h.R * k}
+}
+
+// Len returns the number of steps between the origin and h.
+func (h Hex) Len() int {
+	return max(abs(h.Q), abs(h.R), abs(h.S()))
+}
+
+// Distance returns the number of steps between a and b.
+func Distance(a, b Hex) int {
+	return a.Sub(b).Len()
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// units holds the six steps to the neighbors of a hexagon, going around it.
+// Each step is the sum of its two neighbors in the list.
+var units = [6]Hex{
+	{Q: +1, R: 0},
+	{Q: 0, R: +1},
+	{Q: -1, R: +1},
+	{Q: -1, R: 0},
+	{Q: 0, R: -1},
+	{Q: +1, R: -1},
+}
+
+// Neighbors iterates over the six neighbors of h, going around it. The order
+// is the same as Directions in a PointyTop layout, starting with e.
+func (h Hex) Neighbors() iter.Seq[Hex] {
+	return func(yield func(Hex) bool) {
+		for _, u := range units {
+			if !yield(h.Add(u)) {
+				return
+			}
+		}
+	}
+}
+
+// Ring iterates over the hexagons at distance radius from h, going around it.
+// A radius of 0 yields only h.
+func (h Hex) Ring(radius int) iter.Seq[Hex] {
+	return func(yield func(Hex) bool) {
+		if radius == 0 {
+			yield(h)
+			return
+		}
+		// Start radius steps out in the direction of units[4], and walk radius
+		// steps in each direction in turn, which goes around the ring.
+		p := h.Add(units[4].Scale(radius))
+		for _, u := range units {
+			for range radius {
+				if !yield(p) {
+					return
+				}
+				p = p.Add(u)
+			}
+		}
+	}
+}
+
+// Spiral iterates over the hexagons at distance at most radius from h, ring by
+// ring starting with h itself. There are 3*radius*(radius+1)+1 of them.
+func (h Hex) Spiral(radius int) iter.Seq[Hex] {
+	return func(yield func(Hex) bool) {
+		for r := 0; r <= radius; r++ {
+			for p := range h.Ring(r) {
+				if !yield(p) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Layout is a way to turn the hexagons, which determines the names of the
+// directions and how hexagons are laid out in the plane.
+type Layout int
+
+const (
+	// PointyTop hexagons have a corner pointing up, so they are laid out in
+	// rows. The directions are e, se, sw, w, nw and ne.
+	PointyTop Layout = iota
+	// FlatTop hexagons have an edge at the top, so they are laid out in
+	// columns. The directions are n, ne, se, s, sw and nw.
+	FlatTop
+)
+
+// directions holds the names of the directions in each layout, in the same
+// order as units.
+var directions = [...][6]string{
+	PointyTop: {"e", "se", "sw", "w", "nw", "ne"},
+	FlatTop:   {"se", "s", "sw", "nw", "n", "ne"},
+}
+
+// Directions iterates over the names of the six directions in l and the step
+// each of them takes, going clockwise.
+func (l Layout) Directions() iter.Seq2[string, Hex] {
+	return func(yield func(string, Hex) bool) {
+		for i, name := range directions[l] {
+			if !yield(name, units[i]) {
+				return
+			}
+		}
+	}
+}
+
+// ParseDirection returns the step for the direction named s in l, like "ne".
+func (l Layout) ParseDirection(s string) (Hex, error) {
+	for name, d := range l.Directions() {
+		if name == s {
+			return d, nil
+		}
+	}
+	return Hex{}, fmt.Errorf("hexgrid: %q is not a direction in a %v layout", s, l)
+}
+
+// ParsePath parses a sequence of directions in l into the steps they take. The
+// directions may be separated by commas, like "ne,ne,s", or be written
+// together without separators, like "nwwswee". Surrounding whitespace is
+// ignored.
+func (l Layout) ParsePath(s string) ([]Hex, error) {
+	var path []Hex
+	for token := range strings.SplitSeq(strings.TrimSpace(s), ",") {
+		for rest := strings.TrimSpace(token); rest != ""; {
+			// Directions are one or two letters, and when written together the
+			// two letter ones must be tried first so that "ne" isn't read as
+			// "n" followed by "e".
+			var (
+				d   Hex
+				err error
+			)
+			n := 2
+			if len(rest) >= 2 {
+				d, err = l.ParseDirection(rest[:2])
+			}
+			if len(rest) < 2 || err != nil {
+				n = 1
+				d, err = l.ParseDirection(rest[:1])
+			}
+			if err != nil {
+				return nil, fmt.Errorf("hexgrid: invalid path %q: no direction at %q", s, rest)
+			}
+			path = append(path, d)
+			rest = rest[n:]
+		}
+	}
+	return path, nil
+}
+
+// Pos2 returns the center of h in the plane, in "doubled" coordinates where
+// every hexagon has integer coordinates and Y points down. In a PointyTop
+// layout, hexagons in the same row are 2 apart and rows are 1 apart; in a
+// FlatTop layout, hexagons in the same column are 2 apart and columns are 1
+// apart. That makes it easy to render hexagons in a grid of characters.
+func (l Layout) Pos2(h Hex) geometry.Pos2 {
+	if l == FlatTop {
+		return geometry.Pos2{X: h.Q, Y: 2*h.R + h.Q}
+	}
+	return geometry.Pos2{X: 2*h.Q + h.R, Y: h.R}
+}
+
+func (l Layout) String() string {
+	switch l {
+	case PointyTop:
+		return "PointyTop"
+	case FlatTop:
+		return "FlatTop"
+	default:
+		return fmt.Sprintf("Layout(%d)", int(l))
+	}
+}
+
+// Life runs one generation of a cellular automaton on hexagons, where each
+// hexagon is either alive or dead. alive holds the hexagons that are alive,
+// and next reports whether a hexagon is alive in the next generation given
+// whether it's alive now and how many of its neighbors are. Only hexagons that
+// are alive or have at least one living neighbor are considered, so a hexagon
+// without living neighbors can't come alive.
+func Life(alive set.Set[Hex], next func(alive bool, liveNeighbors int) bool) set.Set[Hex] {
+	counts := make(map[Hex]int, 6*alive.Len())
+	for h := range alive.All() {
+		if _, ok := counts[h]; !ok {
+			counts[h] = 0
+		}
+		for n := range h.Neighbors() {
+			counts[n]++
+		}
+	}
+	result := set.Of[Hex]()
+	for h, n := range counts {
+		if next(alive.Contains(h), n) {
+			result.Add(h)
+		}
+	}
+	return result
+}
@@ -0,0 +1,212 @@
+package hexgrid
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"go.saser.se/adventofgo/container/set"
+	"go.saser.se/adventofgo/geometry"
+)
+
+// walk returns where the path starting at the origin ends, and the furthest
+// distance from the origin along the way.
+func walk(path []Hex) (Hex, int) {
+	var (
+		h        Hex
+		furthest int
+	)
+	for _, d := range path {
+		h = h.Add(d)
+		furthest = max(furthest, h.Len())
+	}
+	return h, furthest
+}
+
+func TestFlatTop_ParsePath_Distance(t *testing.T) {
+	// The examples from Advent of Code 2017 day 11.
+	for _, tt := range []struct {
+		path string
+		want int
+	}{
+		{path: "ne,ne,ne", want: 3},
+		{path: "ne,ne,sw,sw", want: 0},
+		{path: "ne,ne,s,s", want: 2},
+		{path: "se,sw,se,sw,sw", want: 3},
+	} {
+		path, err := FlatTop.ParsePath(tt.path)
+		if err != nil {
+			t.Errorf("FlatTop.ParsePath(%q) err = %v; want nil", tt.path, err)
+			continue
+		}
+		end, _ := walk(path)
+		if got := end.Len(); got != tt.want {
+			t.Errorf("distance after %q = %d; want %d", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestPointyTop_ParsePath(t *testing.T) {
+	// Examples from Advent of Code 2020 day 24, where the directions are
+	// written without separators.
+	for _, tt := range []struct {
+		path string
+		want Hex
+	}{
+		{path: "esew", want: Hex{Q: 0, R: 1}},
+		{path: "nwwswee", want: Hex{}},
+		{path: "e, e", want: Hex{Q: 2, R: 0}},
+		{path: "", want: Hex{}},
+	} {
+		path, err := PointyTop.ParsePath(tt.path)
+		if err != nil {
+			t.Errorf("PointyTop.ParsePath(%q) err = %v; want nil", tt.path, err)
+			continue
+		}
+		if got, _ := walk(path); got != tt.want {
+			t.Errorf("end of %q = %v; want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestParsePath_Error(t *testing.T) {
+	for _, tt := range []struct {
+		l    Layout
+		path string
+	}{
+		{l: PointyTop, path: "n"},
+		{l: PointyTop, path: "ene,s"},
+		{l: FlatTop, path: "e"},
+		{l: FlatTop, path: "nex"},
+	} {
+		if _, err := tt.l.ParsePath(tt.path); err == nil {
+			t.Errorf("%v.ParsePath(%q) succeeded unexpectedly", tt.l, tt.path)
+		}
+	}
+}
+
+func TestDirections(t *testing.T) {
+	for _, l := range []Layout{PointyTop, FlatTop} {
+		var steps []Hex
+		for name, d := range l.Directions() {
+			if got, err := l.ParseDirection(name); err != nil || got != d {
+				t.Errorf("%v.ParseDirection(%q) = %v, %v; want %v, nil", l, name, got, err, d)
+			}
+			steps = append(steps, d)
+		}
+		// Going clockwise, each step is the sum of the ones next to it.
+		for i, d := range steps {
+			prev, next := steps[(i+5)%6], steps[(i+1)%6]
+			if prev.Add(next) != d {
+				t.Errorf("%v: %v + %v != %v", l, prev, next, d)
+			}
+		}
+	}
+}
+
+func TestFromCube(t *testing.T) {
+	h, err := FromCube(1, -3, 2)
+	if err != nil {
+		t.Fatalf("FromCube(1, -3, 2) err = %v; want nil", err)
+	}
+	if h.Q != 1 || h.R != -3 || h.S() != 2 {
+		t.Errorf("FromCube(1, -3, 2) = %v with S = %d", h, h.S())
+	}
+	if _, err := FromCube(1, 1, 1); err == nil {
+		t.Error("FromCube(1, 1, 1) succeeded unexpectedly")
+	}
+}
+
+func TestRing_Spiral(t *testing.T) {
+	center := Hex{Q: 3, R: -2}
+	for radius := range 6 {
+		ring := slices.Collect(center.Ring(radius))
+		if got, want := len(ring), max(6*radius, 1); got != want {
+			t.Errorf("len(Ring(%d)) = %d; want %d", radius, got, want)
+		}
+		for i, h := range ring {
+			if got := Distance(center, h); got != radius {
+				t.Errorf("Ring(%d) has %v at distance %d", radius, h, got)
+			}
+			// Consecutive hexagons in the ring are neighbors.
+			if next := ring[(i+1)%len(ring)]; radius > 0 && Distance(h, next) != 1 {
+				t.Errorf("Ring(%d): %v and %v are not neighbors", radius, h, next)
+			}
+		}
+		spiral := set.Of(slices.Collect(center.Spiral(radius))...)
+		if got, want := spiral.Len(), 3*radius*(radius+1)+1; got != want {
+			t.Errorf("Spiral(%d) has %d distinct hexagons; want %d", radius, got, want)
+		}
+	}
+}
+
+func TestLayout_Pos2(t *testing.T) {
+	for _, l := range []Layout{PointyTop, FlatTop} {
+		// Neighbors must end up at distinct positions next to the center, and
+		// opposite directions at opposite positions.
+		h := Hex{Q: -2, R: 5}
+		seen := set.Of[geometry.Pos2]()
+		for name, d := range l.Directions() {
+			p := l.Pos2(h.Add(d)).Sub(l.Pos2(h))
+			q := l.Pos2(h.Sub(d)).Sub(l.Pos2(h))
+			if p.Add(q) != (geometry.Pos2{}) {
+				t.Errorf("%v: %s and its opposite are at %v and %v", l, name, p, q)
+			}
+			if !seen.Add(p) {
+				t.Errorf("%v: %s is at %v, like another direction", l, name, p)
+			}
+			if p.L1Norm() > 3 {
+				t.Errorf("%v: %s is at %v, too far away", l, name, p)
+			}
+		}
+	}
+	// In a PointyTop layout e is to the right, and in a FlatTop layout n is up.
+	if got, want := PointyTop.Pos2(Hex{Q: 1}), (geometry.Pos2{X: 2, Y: 0}); got != want {
+		t.Errorf("PointyTop.Pos2(e) = %v; want %v", got, want)
+	}
+	if got, want := FlatTop.Pos2(Hex{R: -1}), (geometry.Pos2{X: 0, Y: -2}); got != want {
+		t.Errorf("FlatTop.Pos2(n) = %v; want %v", got, want)
+	}
+}
+
+// bruteLife is Life computed by checking every hexagon near a living one.
+func bruteLife(alive set.Set[Hex], next func(bool, int) bool) set.Set[Hex] {
+	result := set.Of[Hex]()
+	for h := range alive.All() {
+		for c := range h.Spiral(1) {
+			n := 0
+			for nb := range c.Neighbors() {
+				if alive.Contains(nb) {
+					n++
+				}
+			}
+			if next(alive.Contains(c), n) {
+				result.Add(c)
+			}
+		}
+	}
+	return result
+}
+
+func TestLife(t *testing.T) {
+	// The rules from Advent of Code 2020 day 24, where black tiles are alive.
+	rule := func(alive bool, n int) bool {
+		if alive {
+			return n == 1 || n == 2
+		}
+		return n == 2
+	}
+	r := rand.New(rand.NewPCG(1, 2))
+	alive := set.Of[Hex]()
+	for range 30 {
+		alive.Add(Hex{Q: r.IntN(9) - 4, R: r.IntN(9) - 4})
+	}
+	for gen := range 10 {
+		got := Life(alive, rule)
+		want := bruteLife(alive, rule)
+		if got.Len() != want.Len() || set.Minus(got, want).Len() != 0 {
+			t.Fatalf("generation %d: Life() has %d alive; want %d", gen+1, got.Len(), want.Len())
+		}
+		alive = got
+	}
+}
@@ -0,0 +1,240 @@
+// Package rational implements exact rational numbers that are fast while they
+// fit in an int64 and fall back to math/big when they don't.
+//
+// Puzzles like 2023 day 24 intersect lines with coordinates in the hundreds of
+// trillions. Floats round those intersections wrong, and the products needed
+// to find them overflow an int64, but most of the numbers involved are still
+// small.
+package rational
+
+import (
+	"math"
+	"math/big"
+)
+
+// Rat is an exact rational number. The zero value is 0.
+//
+// A Rat is a value and all operations return new values, so Rats can be copied
+// and shared freely. Compare Rats with Cmp or Equal rather than ==, as two
+// equal large values may be represented differently.
+type Rat struct {
+	// num and dm1 hold the numerator and the denominator minus one of the
+	// reduced fraction, so that the zero value is 0/1. Neither is ever
+	// math.MinInt64, so that they can always be negated. They are only used
+	// if big is nil.
+	num, dm1 int64
+	// big holds the number if it doesn't fit in num and dm1. It's never
+	// modified after it's created.
+	big *big.Rat
+}
+
+// Int returns the integer n as a Rat.
+func Int(n int64) Rat {
+	if n == math.MinInt64 {
+		return FromBig(new(big.Rat).SetInt64(n))
+	}
+	return Rat{num: n}
+}
+
+// New returns the fraction num/den. It panics if den is 0.
+func New(num, den int64) Rat {
+	if den == 0 {
+		panic("rational: zero denominator")
+	}
+	if num == math.MinInt64 || den == math.MinInt64 {
+		return FromBig(big.NewRat(num, den))
+	}
+	return small(num, den)
+}
+
+// FromBig returns r as a Rat.
+func FromBig(r *big.Rat) Rat {
+	num, den := r.Num(), r.Denom()
+	if num.IsInt64() && den.IsInt64() {
+		if n := num.Int64(); n != math.MinInt64 {
+			return Rat{num: n, dm1: den.Int64() - 1}
+		}
+	}
+	return Rat{big: new(big.Rat).Set(r)}
+}
+
+// small returns the fraction num/den reduced, where neither is
+// math.MinInt64 and den is not 0.
+func small(num, den int64) Rat {
+	if den < 0 {
+		num, den = -num, -den
+	}
+	if g := gcd(num, den); g > 1 {
+		num, den = num/g, den/g
+	}
+	return Rat{num: num, dm1: den - 1}
+}
+
+// gcd returns the greatest common divisor of |a| and |b|, where neither is
+// math.MinInt64.
+func gcd(a, b int64) int64 {
+	if a < 0 {
+		a = -a
+	}
+	if b < 0 {
+		b = -b
+	}
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// mul returns a*b and true, or false if the result doesn't fit in an int64
+// or is math.MinInt64.
+func mul(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	r := a * b
+	if r/b != a || r == math.MinInt64 {
+		return 0, false
+	}
+	return r, true
+}
+
+// add returns a+b and true, or false if the result doesn't fit in an int64 or
+// is math.MinInt64.
+func add(a, b int64) (int64, bool) {
+	r := a + b
+	if (a > 0 && b > 0 && r < 0) || (a < 0 && b < 0 && r >= 0) || r == math.MinInt64 {
+		return 0, false
+	}
+	return r, true
+}
+
+// Big returns a as a big.Rat. The result is a new value that the caller may
+// modify.
+func (a Rat) Big() *big.Rat {
+	if a.big != nil {
+		return new(big.Rat).Set(a.big)
+	}
+	return big.NewRat(a.num, a.dm1+1)
+}
+
+// Add returns a + b.
+func (a Rat) Add(b Rat) Rat {
+	if a.big == nil && b.big == nil {
+		// a/b + c/d = (a*(d/g) + c*(b/g)) / (b*(d/g)), where g = gcd(b, d).
+		ad, bd := a.dm1+1, b.dm1+1
+		g := gcd(ad, bd)
+		x, ok1 := mul(a.num, bd/g)
+		y, ok2 := mul(b.num, ad/g)
+		num, ok3 := add(x, y)
+		den, ok4 := mul(ad, bd/g)
+		if ok1 && ok2 && ok3 && ok4 {
+			return small(num, den)
+		}
+	}
+	return FromBig(new(big.Rat).Add(a.Big(), b.Big()))
+}
+
+// Neg returns -a.
+func (a Rat) Neg() Rat {
+	if a.big != nil {
+		return FromBig(new(big.Rat).Neg(a.big))
+	}
+	return Rat{num: -a.num, dm1: a.dm1}
+}
+
+// Sub returns a - b.
+func (a Rat) Sub(b Rat) Rat {
+	return a.Add(b.Neg())
+}
+
+// Mul returns a * b.
+func (a Rat) Mul(b Rat) Rat {
+	if a.big == nil && b.big == nil {
+		// Reduce crosswise first, so that the products stay small.
+		ad, bd := a.dm1+1, b.dm1+1
+		g1, g2 := max(gcd(a.num, bd), 1), max(gcd(b.num, ad), 1)
+		num, ok1 := mul(a.num/g1, b.num/g2)
+		den, ok2 := mul(ad/g2, bd/g1)
+		if ok1 && ok2 {
+			return small(num, den)
+		}
+	}
+	return FromBig(new(big.Rat).Mul(a.Big(), b.Big()))
+}
+
+// Inv returns 1 / a. It panics if a is 0.
+func (a Rat) Inv() Rat {
+	if a.Sign() == 0 {
+		panic("rational: division by zero")
+	}
+	if a.big != nil {
+		return FromBig(new(big.Rat).Inv(a.big))
+	}
+	return small(a.dm1+1, a.num)
+}
+
+// Div returns a / b. It panics if b is 0.
+func (a Rat) Div(b Rat) Rat {
+	return a.Mul(b.Inv())
+}
+
+// Sign returns -1, 0 or +1 depending on whether a is negative, zero or
+// positive.
+func (a Rat) Sign() int {
+	if a.big != nil {
+		return a.big.Sign()
+	}
+	switch {
+	case a.num < 0:
+		return -1
+	case a.num > 0:
+		return +1
+	default:
+		return 0
+	}
+}
+
+// Cmp returns -1, 0 or +1 depending on whether a is less than, equal to or
+// greater than b.
+func (a Rat) Cmp(b Rat) int {
+	return a.Sub(b).Sign()
+}
+
+// Equal reports whether a and b are the same number.
+func (a Rat) Equal(b Rat) bool {
+	return a.Cmp(b) == 0
+}
+
+// IsInt reports whether a is an integer.
+func (a Rat) IsInt() bool {
+	if a.big != nil {
+		return a.big.IsInt()
+	}
+	return a.dm1 == 0
+}
+
+// Int64 returns a and true if a is an integer that fits in an int64.
+// Otherwise it returns false.
+func (a Rat) Int64() (int64, bool) {
+	if a.big != nil {
+		if a.big.IsInt() && a.big.Num().IsInt64() {
+			return a.big.Num().Int64(), true
+		}
+		return 0, false
+	}
+	if a.dm1 != 0 {
+		return 0, false
+	}
+	return a.num, true
+}
+
+// Float64 returns the float64 closest to a.
+func (a Rat) Float64() float64 {
+	f, _ := a.Big().Float64()
+	return f
+}
+
+// String returns a as "a/b", or as "a" if it's an integer.
+func (a Rat) String() string {
+	return a.Big().RatString()
+}
@@ -0,0 +1,139 @@
+package rational
+
+import (
+	"math"
+	"math/big"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestRat(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		got  Rat
+		want string
+	}{
+		{name: "Zero", got: Rat{}, want: "0"},
+		{name: "Reduced", got: New(6, -4), want: "-3/2"},
+		{name: "Add", got: New(1, 2).Add(New(1, 3)), want: "5/6"},
+		{name: "Sub", got: New(1, 2).Sub(New(1, 2)), want: "0"},
+		{name: "Mul", got: New(2, 3).Mul(New(9, 4)), want: "3/2"},
+		{name: "Div", got: New(2, 3).Div(New(-4, 9)), want: "-3/2"},
+		{name: "Inv", got: Int(-5).Inv(), want: "-1/5"},
+		{name: "MinInt64", got: Int(math.MinInt64), want: "-9223372036854775808"},
+		{name: "NegMinInt64", got: Int(math.MinInt64).Neg(), want: "9223372036854775808"},
+		{name: "AddOverflow", got: Int(math.MaxInt64).Add(Int(1)), want: "9223372036854775808"},
+		{name: "MulOverflow", got: Int(1 << 40).Mul(Int(1 << 40)), want: "1208925819614629174706176"},
+		{name: "DenominatorOverflow", got: New(1, math.MaxInt64).Add(New(1, math.MaxInt64-1)), want: "18446744073709551613/85070591730234615838173535747377725442"},
+		{name: "BackToSmall", got: Int(math.MaxInt64).Add(Int(1)).Sub(Int(2)), want: "9223372036854775806"},
+		{name: "LargeQuotient", got: Int(1 << 40).Mul(Int(1 << 40)).Div(Int(1 << 41)), want: "549755813888"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.String(); got != tt.want {
+				t.Errorf("got %s; want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRat_BackToSmall(t *testing.T) {
+	// Values that fit in an int64 again after a detour through big.Rat should
+	// be stored as small values, so that later arithmetic is fast.
+	r := Int(math.MaxInt64).Add(Int(1)).Sub(Int(2))
+	if r.big != nil {
+		t.Errorf("%v is stored as a big.Rat; want small", r)
+	}
+	if n, ok := r.Int64(); !ok || n != math.MaxInt64-1 {
+		t.Errorf("%v.Int64() = %d, %v; want %d, true", r, n, ok, int64(math.MaxInt64-1))
+	}
+}
+
+func TestRat_Predicates(t *testing.T) {
+	big := Int(math.MaxInt64).Mul(Int(4))
+	for _, tt := range []struct {
+		r       Rat
+		sign    int
+		isInt   bool
+		int64ok bool
+		float   float64
+	}{
+		{r: Rat{}, sign: 0, isInt: true, int64ok: true, float: 0},
+		{r: New(-7, 2), sign: -1, isInt: false, int64ok: false, float: -3.5},
+		{r: Int(12), sign: +1, isInt: true, int64ok: true, float: 12},
+		{r: big, sign: +1, isInt: true, int64ok: false, float: 4 * math.MaxInt64},
+		{r: big.Div(Int(8)), sign: +1, isInt: false, int64ok: false, float: math.MaxInt64 / 2},
+		{r: big.Div(Int(-4)), sign: -1, isInt: true, int64ok: true, float: -math.MaxInt64},
+	} {
+		if got := tt.r.Sign(); got != tt.sign {
+			t.Errorf("%v.Sign() = %d; want %d", tt.r, got, tt.sign)
+		}
+		if got := tt.r.IsInt(); got != tt.isInt {
+			t.Errorf("%v.IsInt() = %v; want %v", tt.r, got, tt.isInt)
+		}
+		if _, ok := tt.r.Int64(); ok != tt.int64ok {
+			t.Errorf("%v.Int64() ok = %v; want %v", tt.r, ok, tt.int64ok)
+		}
+		if got := tt.r.Float64(); got != tt.float {
+			t.Errorf("%v.Float64() = %v; want %v", tt.r, got, tt.float)
+		}
+	}
+}
+
+func TestRat_DivideByZero(t *testing.T) {
+	for name, f := range map[string]func(){
+		"New": func() { New(1, 0) },
+		"Div": func() { Int(1).Div(Rat{}) },
+		"Inv": func() { Rat{}.Inv() },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s didn't panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestRat_Random(t *testing.T) {
+	// Check every operation against big.Rat, with numbers large enough that
+	// they often overflow.
+	r := rand.New(rand.NewPCG(1, 2))
+	random := func() Rat {
+		den := r.Int64N(1<<r.IntN(62)) + 1
+		num := r.Int64N(1<<r.IntN(62)) - r.Int64N(1<<r.IntN(62))
+		return New(num, den)
+	}
+	ops := []struct {
+		name string
+		rat  func(a, b Rat) Rat
+		big  func(z, a, b *big.Rat) *big.Rat
+	}{
+		{name: "Add", rat: Rat.Add, big: (*big.Rat).Add},
+		{name: "Sub", rat: Rat.Sub, big: (*big.Rat).Sub},
+		{name: "Mul", rat: Rat.Mul, big: (*big.Rat).Mul},
+	}
+	for range 10000 {
+		a := random()
+		// Chain a few operations so that big values show up as inputs too.
+		for range 4 {
+			b := random()
+			op := ops[r.IntN(len(ops))]
+			got := op.rat(a, b)
+			want := op.big(new(big.Rat), a.Big(), b.Big())
+			if got.Big().Cmp(want) != 0 {
+				t.Fatalf("%v.%s(%v) = %v; want %v", a, op.name, b, got, want.RatString())
+			}
+			if got, want := a.Cmp(b), a.Big().Cmp(b.Big()); got != want {
+				t.Fatalf("%v.Cmp(%v) = %d; want %d", a, b, got, want)
+			}
+			if b.Sign() != 0 {
+				if got, want := a.Div(b), new(big.Rat).Quo(a.Big(), b.Big()); got.Big().Cmp(want) != 0 {
+					t.Fatalf("%v.Div(%v) = %v; want %v", a, b, got, want.RatString())
+				}
+			}
+			a = got
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package span
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Set is a set of integers stored as sorted, disjoint spans, which makes it
+// cheap to hold large ranges of numbers, like the positions covered on a row by
+// a handful of sensors. Spans that touch or overlap are merged, so a Set never
+// holds two spans where one ends where the next starts. The zero value is an
+// empty set.
+type Set[T constraints.Integer] struct {
+	spans []Span[T]
+}
+
+// SetOf returns a set holding the numbers in the given spans.
+func SetOf[T constraints.Integer](spans ...Span[T]) *Set[T] {
+	s := new(Set[T])
+	for _, sp := range spans {
+		s.Add(sp)
+	}
+	return s
+}
+
+// search returns the index of the first span in s that ends at or after v.
+func (s *Set[T]) search(v T) int {
+	i, _ := slices.BinarySearchFunc(s.spans, v, func(sp Span[T], v T) int {
+		if sp.End < v {
+			return -1
+		}
+		return +1
+	})
+	return i
+}
+
+// Add adds the numbers in sp to s.
+func (s *Set[T]) Add(sp Span[T]) {
+	if sp.Len() <= 0 {
+		return
+	}
+	// Every span in s[i:j] touches or overlaps sp, and is merged with it.
+	i := s.search(sp.Start)
+	j := i
+	for j < len(s.spans) && s.spans[j].Start <= sp.End {
+		sp.Start = min(sp.Start, s.spans[j].Start)
+		sp.End = max(sp.End, s.spans[j].End)
+		j++
+	}
+	s.spans = slices.Replace(s.spans, i, j, sp)
+}
+
+// Remove removes the numbers in sp from s.
+func (s *Set[T]) Remove(sp Span[T]) {
+	if sp.Len() <= 0 {
+		return
+	}
+	// Every span in s[i:j] overlaps sp, and is replaced by what is left of it
+	// on either side of sp.
+	i := s.search(sp.Start + 1)
+	j := i
+	var left []Span[T]
+	for j < len(s.spans) && s.spans[j].Start < sp.End {
+		if x := New(s.spans[j].Start, sp.Start); x.Len() > 0 {
+			left = append(left, x)
+		}
+		if y := New(sp.End, s.spans[j].End); y.Len() > 0 {
+			left = append(left, y)
+		}
+		j++
+	}
+	s.spans = slices.Replace(s.spans, i, j, left...)
+}
+
+// Contains reports whether v is in s.
+func (s *Set[T]) Contains(v T) bool {
+	i := s.search(v + 1)
+	return i < len(s.spans) && s.spans[i].Contains(v) == 0
+}
+
+// Len returns the number of integers in s.
+func (s *Set[T]) Len() T {
+	var n T
+	for _, sp := range s.spans {
+		n += sp.Len()
+	}
+	return n
+}
+
+// All iterates over the spans of s in increasing order.
+func (s *Set[T]) All() iter.Seq[Span[T]] {
+	return slices.Values(s.spans)
+}
+
+// Gaps iterates over the spans of numbers in within that are not in s, in
+// increasing order.
+func (s *Set[T]) Gaps(within Span[T]) iter.Seq[Span[T]] {
+	return func(yield func(Span[T]) bool) {
+		if within.Len() <= 0 {
+			return
+		}
+		next := within.Start
+		for _, sp := range s.spans[s.search(within.Start+1):] {
+			if sp.Start >= within.End {
+				break
+			}
+			if g := New(next, sp.Start); g.Len() > 0 && !yield(g) {
+				return
+			}
+			next = sp.End
+		}
+		if g := New(next, within.End); g.Len() > 0 {
+			yield(g)
+		}
+	}
+}
+
+// Clone returns a copy of s.
+func (s *Set[T]) Clone() *Set[T] {
+	return &Set[T]{spans: slices.Clone(s.spans)}
+}
+
+func (s *Set[T]) String() string {
+	parts := make([]string, len(s.spans))
+	for i, sp := range s.spans {
+		parts[i] = sp.String()
+	}
+	return fmt.Sprintf("{%s}", strings.Join(parts, ", "))
+}
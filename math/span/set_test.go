@@ -0,0 +1,91 @@
+package span
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSet(t *testing.T) {
+	s := SetOf(New(0, 3), New(5, 8), New(3, 4))
+	if got, want := s.String(), "{[0, 4), [5, 8)}"; got != want {
+		t.Errorf("SetOf(...).String() = %q; want %q", got, want)
+	}
+	s.Add(New(4, 5))
+	if got, want := s.String(), "{[0, 8)}"; got != want {
+		t.Errorf("after Add([4, 5)): String() = %q; want %q", got, want)
+	}
+	s.Remove(New(2, 6))
+	if got, want := s.String(), "{[0, 2), [6, 8)}"; got != want {
+		t.Errorf("after Remove([2, 6)): String() = %q; want %q", got, want)
+	}
+	gaps := slices.Collect(s.Gaps(New(-1, 10)))
+	wantGaps := []Span[int]{New(-1, 0), New(2, 6), New(8, 10)}
+	if diff := cmp.Diff(wantGaps, gaps); diff != "" {
+		t.Errorf("Gaps([-1, 10)) returned unexpected result (-want +got)\n%s", diff)
+	}
+}
+
+func TestSet_Random(t *testing.T) {
+	const n = 40
+	r := rand.New(rand.NewPCG(1, 2))
+	randomSpan := func() Span[int] {
+		return New(r.IntN(n), r.IntN(n))
+	}
+	for range 200 {
+		var (
+			s    Set[int]
+			want [n]bool
+		)
+		for range 20 {
+			sp := randomSpan()
+			add := r.IntN(3) > 0
+			if add {
+				s.Add(sp)
+			} else {
+				s.Remove(sp)
+			}
+			for v := sp.Start; v < sp.End; v++ {
+				want[v] = add
+			}
+
+			wantLen := 0
+			for v := range n {
+				if got := s.Contains(v); got != want[v] {
+					t.Fatalf("%v.Contains(%d) = %v; want %v", &s, v, got, want[v])
+				}
+				if want[v] {
+					wantLen++
+				}
+			}
+			if got := s.Len(); got != wantLen {
+				t.Fatalf("%v.Len() = %d; want %d", &s, got, wantLen)
+			}
+			// The spans must be sorted, and separated by at least one number.
+			spans := slices.Collect(s.All())
+			for i := 1; i < len(spans); i++ {
+				if spans[i-1].End >= spans[i].Start {
+					t.Fatalf("%v: spans %v and %v are not separated", &s, spans[i-1], spans[i])
+				}
+			}
+
+			within := randomSpan()
+			var wantGaps []Span[int]
+			for v := within.Start; v < within.End; v++ {
+				if want[v] {
+					continue
+				}
+				if k := len(wantGaps) - 1; k >= 0 && wantGaps[k].End == v {
+					wantGaps[k].End++
+				} else {
+					wantGaps = append(wantGaps, New(v, v+1))
+				}
+			}
+			if diff := cmp.Diff(wantGaps, slices.Collect(s.Gaps(within))); diff != "" {
+				t.Fatalf("%v.Gaps(%v) returned unexpected result (-want +got)\n%s", &s, within, diff)
+			}
+		}
+	}
+}
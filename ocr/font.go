@@ -0,0 +1,303 @@
+package ocr
+
+// font4x6 is the small font, where letters are 6 rows tall and usually 4
+// columns wide with a blank column in between. It shows up in puzzles like 2016
+// day 8, 2019 days 8 and 11, 2021 day 13 and 2022 day 10.
+var font4x6 = map[rune]string{
+	'A': `
+.##.
+#..#
+#..#
+####
+#..#
+#..#`,
+	'B': `
+###.
+#..#
+###.
+#..#
+#..#
+###.`,
+	'C': `
+.##.
+#..#
+#...
+#...
+#..#
+.##.`,
+	'E': `
+####
+#...
+###.
+#...
+#...
+####`,
+	'F': `
+####
+#...
+###.
+#...
+#...
+#...`,
+	'G': `
+.##.
+#..#
+#...
+#.##
+#..#
+.###`,
+	'H': `
+#..#
+#..#
+####
+#..#
+#..#
+#..#`,
+	'I': `
+###
+.#.
+.#.
+.#.
+.#.
+###`,
+	'J': `
+..##
+...#
+...#
+...#
+#..#
+.##.`,
+	'K': `
+#..#
+#.#.
+##..
+#.#.
+#.#.
+#..#`,
+	'L': `
+#...
+#...
+#...
+#...
+#...
+####`,
+	'O': `
+.##.
+#..#
+#..#
+#..#
+#..#
+.##.`,
+	'P': `
+###.
+#..#
+#..#
+###.
+#...
+#...`,
+	'R': `
+###.
+#..#
+#..#
+###.
+#.#.
+#..#`,
+	'S': `
+.###
+#...
+#...
+.##.
+...#
+###.`,
+	'U': `
+#..#
+#..#
+#..#
+#..#
+#..#
+.##.`,
+	'Y': `
+#...#
+#...#
+.#.#.
+..#..
+..#..
+..#..`,
+	'Z': `
+####
+...#
+..#.
+.#..
+#...
+####`,
+}
+
+// font6x10 is the large font, where letters are 10 rows tall and 6 columns wide
+// with two blank columns in between. It shows up in puzzles like 2018 day 10.
+var font6x10 = map[rune]string{
+	'A': `
+..##..
+.#..#.
+#....#
+#....#
+#....#
+######
+#....#
+#....#
+#....#
+#....#`,
+	'B': `
+#####.
+#....#
+#....#
+#....#
+#####.
+#....#
+#....#
+#....#
+#....#
+#####.`,
+	'C': `
+.####.
+#....#
+#.....
+#.....
+#.....
+#.....
+#.....
+#.....
+#....#
+.####.`,
+	'E': `
+######
+#.....
+#.....
+#.....
+#####.
+#.....
+#.....
+#.....
+#.....
+######`,
+	'F': `
+######
+#.....
+#.....
+#.....
+#####.
+#.....
+#.....
+#.....
+#.....
+#.....`,
+	'G': `
+.####.
+#....#
+#.....
+#.....
+#.....
+#..###
+#....#
+#....#
+#...##
+.###.#`,
+	'H': `
+#....#
+#....#
+#....#
+#....#
+######
+#....#
+#....#
+#....#
+#....#
+#....#`,
+	'J': `
+...###
+....#.
+....#.
+....#.
+....#.
+....#.
+....#.
+#...#.
+#...#.
+.###..`,
+	'K': `
+#....#
+#...#.
+#..#..
+#.#...
+##....
+##....
+#.#...
+#..#..
+#...#.
+#....#`,
+	'L': `
+#.....
+#.....
+#.....
+#.....
+#.....
+#.....
+#.....
+#.....
+#.....
+######`,
+	'N': `
+#....#
+##...#
+##...#
+#.#..#
+#.#..#
+#..#.#
+#..#.#
+#...##
+#...##
+#....#`,
+	'P': `
+#####.
+#....#
+#....#
+#....#
+#####.
+#.....
+#.....
+#.....
+#.....
+#.....`,
+	'R': `
+#####.
+#....#
+#....#
+#....#
+#####.
+#..#..
+#...#.
+#...#.
+#....#
+#....#`,
+	'X': `
+#....#
+#....#
+.#..#.
+.#..#.
+..##..
+..##..
+.#..#.
+.#..#.
+#....#
+#....#`,
+	'Z': `
+######
+.....#
+.....#
+....#.
+...#..
+..#...
+.#....
+#.....
+#.....
+######`,
+}
@@ -0,0 +1,165 @@
+// Package ocr recognizes the block letters that some Advent of Code puzzles
+// draw as their answer, so that solvers can return the answer as a string
+// instead of a picture for a human to read.
+//
+// It knows the two fonts used by the puzzles: a small one where letters are 6
+// rows tall and about 4 columns wide, and a large one where they are 10 rows
+// tall and 6 columns wide. The text can be given as a grid, a set of points,
+// or a slice of rows of booleans.
+package ocr
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+
+	"go.saser.se/adventofgo/asciigrid"
+)
+
+// glyph is a letter in a font, with each column stored as a bitmask where bit i
+// is set if row i is lit.
+type glyph struct {
+	r    rune
+	cols []uint16
+}
+
+// font is a set of glyphs that are all the same height.
+type font struct {
+	height int
+	// glyphs is sorted by width, widest first, so that the widest glyph that
+	// matches is preferred.
+	glyphs []glyph
+}
+
+func parseFont(m map[rune]string) font {
+	var f font
+	for r, s := range m {
+		rows := strings.Split(strings.TrimSpace(s), "\n")
+		f.height = len(rows)
+		g := glyph{r: r, cols: make([]uint16, len(rows[0]))}
+		for row, line := range rows {
+			for col, c := range line {
+				if c == '#' {
+					g.cols[col] |= 1 << row
+				}
+			}
+		}
+		f.glyphs = append(f.glyphs, g)
+	}
+	slices.SortFunc(f.glyphs, func(a, b glyph) int {
+		if c := cmp.Compare(len(b.cols), len(a.cols)); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.r, b.r)
+	})
+	return f
+}
+
+var fonts = []font{
+	parseFont(font4x6),
+	parseFont(font6x10),
+}
+
+// FromGrid reads the text in g, where '#' is lit and every other byte is not.
+func FromGrid(g *asciigrid.Grid) (string, error) {
+	var ps []asciigrid.Pos
+	for p, b := range g.All() {
+		if b == '#' {
+			ps = append(ps, p)
+		}
+	}
+	return recognize(ps)
+}
+
+// FromPoints reads the text made up of the lit points in ps. The points may be
+// anywhere, as only their positions relative to each other matter.
+func FromPoints(ps iter.Seq[asciigrid.Pos]) (string, error) {
+	return recognize(slices.Collect(ps))
+}
+
+// FromBools reads the text in pixels, where pixels[row][col] reports whether
+// the pixel at that row and column is lit. The rows may have different
+// lengths.
+func FromBools(pixels [][]bool) (string, error) {
+	var ps []asciigrid.Pos
+	for row, line := range pixels {
+		for col, lit := range line {
+			if lit {
+				ps = append(ps, asciigrid.Pos{Row: row, Col: col})
+			}
+		}
+	}
+	return recognize(ps)
+}
+
+// recognize reads the text made up of the lit pixels ps. If some glyphs are not
+// letters in the font, it returns an error for each of them together with the
+// text where they are replaced by '?'.
+func recognize(ps []asciigrid.Pos) (string, error) {
+	if len(ps) == 0 {
+		return "", errors.New("ocr: no lit pixels")
+	}
+	r := asciigrid.RectOf(ps...)
+	var f *font
+	for i := range fonts {
+		if fonts[i].height == r.NRows() {
+			f = &fonts[i]
+		}
+	}
+	if f == nil {
+		return "", fmt.Errorf("ocr: text is %d rows tall, but the known fonts are 6 and 10 rows tall", r.NRows())
+	}
+	cols := make([]uint16, r.NCols())
+	for _, p := range ps {
+		cols[p.Col-r.Min.Col] |= 1 << (p.Row - r.Min.Row)
+	}
+	var (
+		sb   strings.Builder
+		errs []error
+	)
+	for x := 0; x < len(cols); {
+		if cols[x] == 0 {
+			x++
+			continue
+		}
+		w := 0
+		for _, g := range f.glyphs {
+			if x+len(g.cols) <= len(cols) && slices.Equal(cols[x:x+len(g.cols)], g.cols) {
+				sb.WriteRune(g.r)
+				w = len(g.cols)
+				break
+			}
+		}
+		if w == 0 {
+			// Skip to the next blank column, which is most likely where the
+			// unknown glyph ends.
+			for w = 1; x+w < len(cols) && cols[x+w] != 0; w++ {
+			}
+			sb.WriteByte('?')
+			errs = append(errs, fmt.Errorf("ocr: unknown glyph at column %d:\n%s", x, drawColumns(cols[x:x+w], f.height)))
+		}
+		x += w
+	}
+	return sb.String(), errors.Join(errs...)
+}
+
+// drawColumns draws columns of pixels as text with '#' for lit pixels.
+func drawColumns(cols []uint16, height int) string {
+	var sb strings.Builder
+	for row := range height {
+		if row > 0 {
+			sb.WriteByte('\n')
+		}
+		for _, c := range cols {
+			if c&(1<<row) != 0 {
+				sb.WriteByte('#')
+			} else {
+				sb.WriteByte('.')
+			}
+		}
+	}
+	return sb.String()
+}
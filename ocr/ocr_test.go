@@ -0,0 +1,183 @@
+package ocr
+
+import (
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"go.saser.se/adventofgo/asciigrid"
+)
+
+// draw draws text in the font f, with gap blank columns between letters and
+// offset blank columns before the first one.
+func draw(t *testing.T, f map[rune]string, text string, gap, offset int) *asciigrid.Grid {
+	t.Helper()
+	var rows []string
+	for i, r := range text {
+		s, ok := f[r]
+		if !ok {
+			t.Fatalf("font has no %q", r)
+		}
+		lines := strings.Split(strings.TrimSpace(s), "\n")
+		if rows == nil {
+			rows = make([]string, len(lines))
+			for j := range rows {
+				rows[j] = strings.Repeat(".", offset)
+			}
+		}
+		for j, line := range lines {
+			if i > 0 {
+				rows[j] += strings.Repeat(".", gap)
+			}
+			rows[j] += line
+		}
+	}
+	return asciigrid.MustNew(strings.Join(rows, "\n"))
+}
+
+// letters returns all letters of the font f in order.
+func letters(f map[rune]string) string {
+	return string(slices.Sorted(maps.Keys(f)))
+}
+
+func TestFromGrid_AllLetters(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		font map[rune]string
+		gap  int
+	}{
+		{name: "4x6", font: font4x6, gap: 1},
+		{name: "6x10", font: font6x10, gap: 2},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			want := letters(tt.font)
+			// Try the letters in order, in reverse, and shuffled, so that
+			// every letter has a few different neighbors.
+			r := rand.New(rand.NewPCG(1, 2))
+			shuffled := []rune(want)
+			r.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+			reversed := []rune(want)
+			slices.Reverse(reversed)
+			for _, text := range []string{want, string(reversed), string(shuffled)} {
+				g := draw(t, tt.font, text, tt.gap, 3)
+				got, err := FromGrid(g)
+				if err != nil {
+					t.Errorf("FromGrid(%q) err = %v; want nil", text, err)
+				}
+				if got != text {
+					t.Errorf("FromGrid() = %q; want %q", got, text)
+				}
+			}
+		})
+	}
+}
+
+func TestFromGrid_Example(t *testing.T) {
+	// The letters are 4 columns wide in a 5 column stride, like in the
+	// puzzles, so I has a blank column on either side.
+	g := asciigrid.MustNew(`
+.##..###...##..####.####..##..#..#.###...
+#..#.#..#.#..#.#....#....#..#.#..#..#....
+#..#.###..#....###..###..#....####..#....
+####.#..#.#....#....#....#.##.#..#..#....
+#..#.#..#.#..#.#....#....#..#.#..#..#....
+#..#.###...##..####.#.....###.#..#.###...
+`)
+	got, err := FromGrid(g)
+	if err != nil {
+		t.Fatalf("FromGrid() err = %v; want nil", err)
+	}
+	if want := "ABCEFGHI"; got != want {
+		t.Errorf("FromGrid() = %q; want %q", got, want)
+	}
+}
+
+func TestFromGrid_NoGap(t *testing.T) {
+	// Y is 5 columns wide, so in a 5 column stride it touches the next letter.
+	g := draw(t, font4x6, "YZY", 0, 0)
+	got, err := FromGrid(g)
+	if err != nil {
+		t.Fatalf("FromGrid() err = %v; want nil", err)
+	}
+	if want := "YZY"; got != want {
+		t.Errorf("FromGrid() = %q; want %q", got, want)
+	}
+}
+
+func TestFromPoints(t *testing.T) {
+	g := draw(t, font6x10, "HAXZ", 2, 0)
+	var ps []asciigrid.Pos
+	for p, b := range g.All() {
+		if b == '#' {
+			// Move the points somewhere else, as only their relative
+			// positions matter.
+			ps = append(ps, asciigrid.Pos{Row: p.Row - 100, Col: p.Col + 37})
+		}
+	}
+	rand.New(rand.NewPCG(1, 2)).Shuffle(len(ps), func(i, j int) { ps[i], ps[j] = ps[j], ps[i] })
+	got, err := FromPoints(slices.Values(ps))
+	if err != nil {
+		t.Fatalf("FromPoints() err = %v; want nil", err)
+	}
+	if want := "HAXZ"; got != want {
+		t.Errorf("FromPoints() = %q; want %q", got, want)
+	}
+}
+
+func TestFromBools(t *testing.T) {
+	g := draw(t, font4x6, "JUKO", 1, 0)
+	pixels := make([][]bool, g.NRows())
+	for p, b := range g.All() {
+		pixels[p.Row] = append(pixels[p.Row], b == '#')
+	}
+	// Ragged rows are fine.
+	pixels[2] = append(pixels[2], false, false)
+	got, err := FromBools(pixels)
+	if err != nil {
+		t.Fatalf("FromBools() err = %v; want nil", err)
+	}
+	if want := "JUKO"; got != want {
+		t.Errorf("FromBools() = %q; want %q", got, want)
+	}
+}
+
+func TestFromGrid_UnknownGlyph(t *testing.T) {
+	g := asciigrid.MustNew(`
+#..#.#..#.#..#
+#..#.##.#.#..#
+####.#.##.####
+#..#.#..#.#..#
+#..#.#..#.#..#
+#..#.#..#.#..#
+`)
+	got, err := FromGrid(g)
+	if err == nil {
+		t.Fatal("FromGrid() succeeded unexpectedly")
+	}
+	if want := "H?H"; got != want {
+		t.Errorf("FromGrid() = %q; want %q", got, want)
+	}
+	wantErr := `ocr: unknown glyph at column 5:
+#..#
+##.#
+#.##
+#..#
+#..#
+#..#`
+	if err.Error() != wantErr {
+		t.Errorf("FromGrid() err = %q; want %q", err, wantErr)
+	}
+}
+
+func TestFromGrid_Error(t *testing.T) {
+	for _, s := range []string{
+		"....\n....",
+		"#..#\n####\n#..#",
+	} {
+		if got, err := FromGrid(asciigrid.MustNew(s)); err == nil {
+			t.Errorf("FromGrid(%q) = %q; want error", s, got)
+		}
+	}
+}
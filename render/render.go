@@ -0,0 +1,149 @@
+// Package render turns grids into images, to look at the state of a puzzle or
+// a simulation when the answer can only be recognized visually. It can write
+// single grids as PNG and sequences of grids as animated GIF.
+package render
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+	"iter"
+	"slices"
+	"time"
+
+	"go.saser.se/adventofgo/asciigrid"
+)
+
+// Palette maps the bytes in a grid to the colors they're drawn with.
+type Palette map[byte]color.Color
+
+// DefaultPalette draws '#' as white and '.' as black, which suits most puzzles
+// where '#' marks a wall or a lit pixel.
+var DefaultPalette = Palette{
+	'#': color.White,
+	'.': color.Black,
+}
+
+// Options controls how grids are drawn.
+type Options struct {
+	// Palette holds the colors to draw bytes with. A nil Palette means
+	// DefaultPalette. At most 255 bytes can have colors of their own.
+	Palette Palette
+	// Default is the color to draw bytes that aren't in the palette with. A
+	// nil Default means gray.
+	Default color.Color
+	// CellSize is the width and height in pixels of each cell. Values less
+	// than 1 mean 1.
+	CellSize int
+}
+
+// colors returns the palette to use for images, and the index in it of each
+// byte value. Index 0 is the default color.
+func (o Options) colors() (color.Palette, *[256]uint8, error) {
+	p := o.Palette
+	if p == nil {
+		p = DefaultPalette
+	}
+	if len(p) > 255 {
+		return nil, nil, errors.New("render: palette has more than 255 colors")
+	}
+	def := o.Default
+	if def == nil {
+		def = color.Gray{Y: 0x80}
+	}
+	pal := color.Palette{def}
+	var index [256]uint8
+	// Add the colors in order of the bytes, so that the output is the same
+	// every time.
+	bs := make([]byte, 0, len(p))
+	for b := range p {
+		bs = append(bs, b)
+	}
+	slices.Sort(bs)
+	for _, b := range bs {
+		index[b] = uint8(len(pal))
+		pal = append(pal, p[b])
+	}
+	return pal, &index, nil
+}
+
+func (o Options) cellSize() int {
+	return max(o.CellSize, 1)
+}
+
+// Image draws g as an image.
+func (o Options) Image(g *asciigrid.Grid) (*image.Paletted, error) {
+	pal, index, err := o.colors()
+	if err != nil {
+		return nil, err
+	}
+	return o.draw(g, pal, index), nil
+}
+
+func (o Options) draw(g *asciigrid.Grid, pal color.Palette, index *[256]uint8) *image.Paletted {
+	size := o.cellSize()
+	img := image.NewPaletted(image.Rect(0, 0, g.NCols()*size, g.NRows()*size), pal)
+	for p, b := range g.All() {
+		c := index[b]
+		for y := p.Row * size; y < (p.Row+1)*size; y++ {
+			row := img.Pix[y*img.Stride:]
+			for x := p.Col * size; x < (p.Col+1)*size; x++ {
+				row[x] = c
+			}
+		}
+	}
+	return img
+}
+
+// PNG writes g to w as a PNG image.
+func PNG(w io.Writer, g *asciigrid.Grid, opts Options) error {
+	img, err := opts.Image(g)
+	if err != nil {
+		return err
+	}
+	return png.Encode(w, img)
+}
+
+// GIF writes the frames to w as an animated GIF that shows each frame for delay
+// and then loops. The frames may have different sizes, in which case the
+// animation is as large as the largest of them and smaller frames are drawn in
+// the top-left corner. GIF only supports delays in multiples of 10ms, so delay
+// is rounded down to one, but is at least 10ms.
+func GIF(w io.Writer, frames iter.Seq[*asciigrid.Grid], opts Options, delay time.Duration) error {
+	pal, index, err := opts.colors()
+	if err != nil {
+		return err
+	}
+	centis := max(int(delay/(10*time.Millisecond)), 1)
+	anim := &gif.GIF{}
+	for g := range frames {
+		img := opts.draw(g, pal, index)
+		anim.Image = append(anim.Image, img)
+		anim.Delay = append(anim.Delay, centis)
+		anim.Config.Width = max(anim.Config.Width, img.Rect.Dx())
+		anim.Config.Height = max(anim.Config.Height, img.Rect.Dy())
+	}
+	if len(anim.Image) == 0 {
+		return errors.New("render: no frames to write")
+	}
+	anim.Config.ColorModel = pal
+	return gif.EncodeAll(w, anim)
+}
+
+// Points returns a grid covering bounds, where the given positions are '#' and
+// all other positions are '.'. The position bounds.Min is at (0, 0) in the
+// grid, and positions outside bounds are left out. It turns point sets into
+// grids that can be drawn with DefaultPalette. Using the same bounds for every
+// frame of an animation keeps the points in place.
+func Points(ps iter.Seq[asciigrid.Pos], bounds asciigrid.Rect) *asciigrid.Grid {
+	g := asciigrid.Make(bounds.NRows(), bounds.NCols(), byte('.'))
+	for p := range ps {
+		if bounds.Contains(p) {
+			g.Set(asciigrid.Pos{Row: p.Row - bounds.Min.Row, Col: p.Col - bounds.Min.Col}, '#')
+		}
+	}
+	return g
+}
@@ -0,0 +1,140 @@
+package render
+
+import (
+	"bytes"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"slices"
+	"testing"
+	"time"
+
+	"go.saser.se/adventofgo/asciigrid"
+)
+
+var (
+	red  = color.RGBA{R: 0xff, A: 0xff}
+	blue = color.RGBA{B: 0xff, A: 0xff}
+)
+
+// sameColor reports whether a and b are the same color.
+func sameColor(a, b color.Color) bool {
+	r1, g1, b1, a1 := a.RGBA()
+	r2, g2, b2, a2 := b.RGBA()
+	return r1 == r2 && g1 == g2 && b1 == b2 && a1 == a2
+}
+
+func TestPNG(t *testing.T) {
+	g := asciigrid.MustNew("#.x\n..#")
+	opts := Options{
+		Palette:  Palette{'#': red, '.': color.Black},
+		Default:  blue,
+		CellSize: 3,
+	}
+	var buf bytes.Buffer
+	if err := PNG(&buf, g, opts); err != nil {
+		t.Fatalf("PNG() err = %v; want nil", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("png.Decode() err = %v; want nil", err)
+	}
+	if got, want := img.Bounds().Dx(), 9; got != want {
+		t.Errorf("width = %d; want %d", got, want)
+	}
+	if got, want := img.Bounds().Dy(), 6; got != want {
+		t.Errorf("height = %d; want %d", got, want)
+	}
+	want := map[byte]color.Color{'#': red, '.': color.Black, 'x': blue}
+	for p, b := range g.All() {
+		// Check every pixel in the cell.
+		for y := p.Row * 3; y < (p.Row+1)*3; y++ {
+			for x := p.Col * 3; x < (p.Col+1)*3; x++ {
+				if got := img.At(x, y); !sameColor(got, want[b]) {
+					t.Errorf("pixel (%d, %d) in cell %v holding %q = %v; want %v", x, y, p, b, got, want[b])
+				}
+			}
+		}
+	}
+}
+
+func TestPNG_Deterministic(t *testing.T) {
+	g := asciigrid.MustNew("abc\ndef")
+	opts := Options{Palette: Palette{'a': red, 'b': blue, 'c': color.White, 'd': color.Black}}
+	var first []byte
+	for i := range 10 {
+		var buf bytes.Buffer
+		if err := PNG(&buf, g, opts); err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			first = buf.Bytes()
+		} else if !bytes.Equal(buf.Bytes(), first) {
+			t.Fatalf("PNG() gave different output on attempt %d", i)
+		}
+	}
+}
+
+func TestPNG_PaletteTooLarge(t *testing.T) {
+	p := make(Palette)
+	for b := range 256 {
+		p[byte(b)] = color.White
+	}
+	var buf bytes.Buffer
+	if err := PNG(&buf, asciigrid.MustNew("#"), Options{Palette: p}); err == nil {
+		t.Error("PNG() with 256 colors in the palette succeeded unexpectedly")
+	}
+}
+
+func TestGIF(t *testing.T) {
+	frames := []*asciigrid.Grid{
+		asciigrid.MustNew("#.\n.."),
+		asciigrid.MustNew(".#\n.."),
+		asciigrid.MustNew("...\n.#."),
+	}
+	var buf bytes.Buffer
+	if err := GIF(&buf, slices.Values(frames), Options{CellSize: 2}, 50*time.Millisecond); err != nil {
+		t.Fatalf("GIF() err = %v; want nil", err)
+	}
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatalf("gif.DecodeAll() err = %v; want nil", err)
+	}
+	if got, want := len(anim.Image), len(frames); got != want {
+		t.Fatalf("number of frames = %d; want %d", got, want)
+	}
+	if got, want := anim.Config.Width, 6; got != want {
+		t.Errorf("width = %d; want %d", got, want)
+	}
+	if got, want := anim.Config.Height, 4; got != want {
+		t.Errorf("height = %d; want %d", got, want)
+	}
+	for i, g := range frames {
+		if got, want := anim.Delay[i], 5; got != want {
+			t.Errorf("delay of frame %d = %d; want %d", i, got, want)
+		}
+		for p, b := range g.All() {
+			want := DefaultPalette[b]
+			if got := anim.Image[i].At(p.Col*2+1, p.Row*2+1); !sameColor(got, want) {
+				t.Errorf("frame %d: cell %v holding %q has color %v; want %v", i, p, b, got, want)
+			}
+		}
+	}
+}
+
+func TestGIF_NoFrames(t *testing.T) {
+	var buf bytes.Buffer
+	if err := GIF(&buf, slices.Values([]*asciigrid.Grid(nil)), Options{}, time.Second); err == nil {
+		t.Error("GIF() without frames succeeded unexpectedly")
+	}
+}
+
+func TestPoints(t *testing.T) {
+	ps := []asciigrid.Pos{{Row: -1, Col: 2}, {Row: 0, Col: 0}, {Row: 1, Col: 3}, {Row: 5, Col: 5}}
+	bounds := asciigrid.Rect{Min: asciigrid.Pos{Row: -1, Col: 0}, Max: asciigrid.Pos{Row: 2, Col: 4}}
+	got := Points(slices.Values(ps), bounds).String()
+	want := "..#.\n#...\n...#"
+	if got != want {
+		t.Errorf("Points() = %q; want %q", got, want)
+	}
+}
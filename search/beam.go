@@ -0,0 +1,98 @@
+package search
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// Beam uses beam search to look for a cheap path from start to a goal. Like
+// BFS, it searches one level at a time, where a level is the states that are
+// the same number of steps away from the start. Unlike BFS, it only keeps the
+// width most promising states of each level and forgets about the rest, which
+// bounds the memory use of the frontier. A state is more promising the lower
+// its cost so far plus estimate(state) is; a nil estimate ranks states by their
+// cost so far alone. neighbors and isGoal are the same as for Dijkstra, and
+// width must be positive.
+//
+// Since it forgets about states, Beam may find a path that is not the
+// cheapest, or no path even though there is one. A wider beam makes that less
+// likely but the search slower. It is still useful for finding a reasonably
+// good answer to a problem where the exact searches run out of memory, or as an
+// upper bound to prune other searches with.
+//
+// Beam remembers the cheapest cost at which each state has been in the beam,
+// so that it doesn't go around in circles. That takes memory proportional to
+// width times the number of levels. It stops when no state in the frontier is
+// cheaper than the cheapest goal found so far.
+func Beam[S comparable](start S, neighbors func(S) iter.Seq2[S, int], isGoal func(S) bool, estimate func(S) int, width int) PathResult[S] {
+	// beamNode is a state together with how it was reached. The nodes form
+	// chains back to the start, which the garbage collector cleans up once the
+	// beam no longer includes them.
+	type beamNode struct {
+		State    S
+		Cost     int
+		Priority int
+		Parent   *beamNode
+	}
+	h := func(s S) int {
+		if estimate == nil {
+			return 0
+		}
+		return estimate(s)
+	}
+	var (
+		res      PathResult[S]
+		best     *beamNode
+		cheapest = map[S]int{start: 0}
+		frontier = []*beamNode{{State: start, Priority: h(start)}}
+	)
+	for len(frontier) > 0 {
+		res.Stats.observe(len(frontier))
+		var (
+			next []*beamNode
+			// index holds the index of each state in next, so that only the
+			// cheapest way of reaching a state is kept.
+			index = make(map[S]int)
+		)
+		for _, b := range frontier {
+			if best != nil && b.Cost >= best.Cost {
+				continue
+			}
+			if isGoal != nil && isGoal(b.State) {
+				best = b
+				continue
+			}
+			res.Stats.Expanded++
+			for n, cost := range neighbors(b.State) {
+				c := b.Cost + cost
+				if prev, ok := cheapest[n]; ok && prev <= c {
+					continue
+				}
+				nb := &beamNode{State: n, Cost: c, Priority: c + h(n), Parent: b}
+				if i, ok := index[n]; ok {
+					if next[i].Cost > c {
+						next[i] = nb
+					}
+					continue
+				}
+				index[n] = len(next)
+				next = append(next, nb)
+			}
+		}
+		slices.SortStableFunc(next, func(a, b *beamNode) int { return cmp.Compare(a.Priority, b.Priority) })
+		frontier = next[:min(len(next), width)]
+		for _, b := range frontier {
+			cheapest[b.State] = b.Cost
+		}
+	}
+	if best == nil {
+		return res
+	}
+	for b := best; b != nil; b = b.Parent {
+		res.Path = append(res.Path, b.State)
+	}
+	slices.Reverse(res.Path)
+	res.Cost = best.Cost
+	return res
+}
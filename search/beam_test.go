@@ -0,0 +1,58 @@
+package search
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestBeam(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	for range 20 {
+		start := scramble(rng, 40)
+		want, _ := BFS(start, puzzle.neighbors, isSolved).Dist(solved)
+		for _, width := range []int{1, 10, 1000} {
+			res := Beam(start, puzzle.weightedNeighbors, isSolved, puzzle.manhattan, width)
+			if !res.Found() {
+				if width >= 1000 {
+					t.Errorf("Beam(%v, width: %v) found no path; want one", start, width)
+				}
+				continue
+			}
+			if res.Cost < want {
+				t.Errorf("Beam(%v, width: %v) found a path with cost %v; want at least %v", start, width, res.Cost, want)
+			}
+			if width >= 1000 && res.Cost != want {
+				t.Errorf("Beam(%v, width: %v) found a path with cost %v; want %v", start, width, res.Cost, want)
+			}
+			if got := res.Stats.PeakFrontier; got > width {
+				t.Errorf("Beam(%v, width: %v) had a frontier of %v states; want at most %v", start, width, got, width)
+			}
+			checkPuzzlePath(t, start, res.Path)
+		}
+	}
+}
+
+// BenchmarkStrategies compares the strategies on the same puzzle, and reports
+// how much work each did.
+func BenchmarkStrategies(b *testing.B) {
+	start := scramble(rand.New(rand.NewPCG(1, 2)), 1000)
+	for _, bm := range []struct {
+		name string
+		run  func() Stats
+	}{
+		{name: "BFS", run: func() Stats { return BFS(start, puzzle.neighbors, isSolved).Stats() }},
+		{name: "AStar", run: func() Stats { return AStar(start, puzzle.weightedNeighbors, isSolved, puzzle.manhattan).Stats() }},
+		{name: "IDAStar", run: func() Stats { return IDAStar(start, puzzle.weightedNeighbors, isSolved, puzzle.manhattan).Stats }},
+		{name: "BidirectionalBFS", run: func() Stats { return BidirectionalBFS(start, solved, puzzle.neighbors, puzzle.neighbors).Stats }},
+		{name: "Beam", run: func() Stats { return Beam(start, puzzle.weightedNeighbors, isSolved, puzzle.manhattan, 100).Stats }},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			var st Stats
+			for b.Loop() {
+				st = bm.run()
+			}
+			b.ReportMetric(float64(st.Expanded), "expanded")
+			b.ReportMetric(float64(st.PeakFrontier), "peak-frontier")
+		})
+	}
+}
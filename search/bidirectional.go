@@ -0,0 +1,85 @@
+package search
+
+import (
+	"iter"
+	"slices"
+)
+
+// BidirectionalBFS finds a shortest path from start to goal where every step
+// costs 1, by searching forwards from start and backwards from goal at the same
+// time until the two searches meet. neighbors iterates over the states one step
+// away from a given state, and predecessors iterates over the states that are
+// one step away from a given state. In graphs where every step can be taken in
+// both directions, the two are the same.
+//
+// If every state has about b neighbors and the shortest path has length d, a
+// plain breadth-first search reaches around b^d states while a bidirectional
+// one reaches around 2b^(d/2). PeakFrontier in the returned stats is the largest
+// number of states the two searches had reached in total.
+func BidirectionalBFS[S comparable](start, goal S, neighbors, predecessors func(S) iter.Seq[S]) PathResult[S] {
+	var res PathResult[S]
+	if start == goal {
+		res.Path = []S{start}
+		return res
+	}
+	// Each side of the search keeps track of the state it came from to reach
+	// each state, which for the backward side is the next state on the way to
+	// the goal.
+	type side struct {
+		frontier []S
+		parent   map[S]S
+		next     func(S) iter.Seq[S]
+	}
+	fwd := &side{frontier: []S{start}, parent: map[S]S{start: start}, next: neighbors}
+	bwd := &side{frontier: []S{goal}, parent: map[S]S{goal: goal}, next: predecessors}
+	for len(fwd.frontier) > 0 && len(bwd.frontier) > 0 {
+		// Expand all of the smaller frontier, one level further away from its
+		// origin.
+		this, other := fwd, bwd
+		if len(bwd.frontier) < len(fwd.frontier) {
+			this, other = bwd, fwd
+		}
+		var (
+			next  []S
+			meet  S
+			found bool
+		)
+		for _, s := range this.frontier {
+			res.Stats.Expanded++
+			for n := range this.next(s) {
+				if _, ok := this.parent[n]; ok {
+					continue
+				}
+				this.parent[n] = s
+				next = append(next, n)
+				// Every state in the other frontier is equally far from the
+				// other origin, so the first state where they meet gives a
+				// shortest path.
+				if _, ok := other.parent[n]; ok {
+					meet, found = n, true
+					break
+				}
+			}
+			if found {
+				break
+			}
+		}
+		this.frontier = next
+		res.Stats.observe(len(fwd.parent) + len(bwd.parent))
+		if !found {
+			continue
+		}
+		for s := meet; s != start; s = fwd.parent[s] {
+			res.Path = append(res.Path, s)
+		}
+		res.Path = append(res.Path, start)
+		slices.Reverse(res.Path)
+		for s := meet; s != goal; {
+			s = bwd.parent[s]
+			res.Path = append(res.Path, s)
+		}
+		res.Cost = len(res.Path) - 1
+		return res
+	}
+	return res
+}
@@ -0,0 +1,66 @@
+package search
+
+import (
+	"iter"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.saser.se/adventofgo/asciigrid"
+)
+
+func TestBidirectionalBFS(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	for range 20 {
+		start := scramble(rng, 40)
+		bfs := BFS(start, puzzle.neighbors, isSolved)
+		want, _ := bfs.Dist(solved)
+		res := BidirectionalBFS(start, solved, puzzle.neighbors, puzzle.neighbors)
+		if !res.Found() {
+			t.Errorf("BidirectionalBFS(%v) found no path; want one with cost %v", start, want)
+			continue
+		}
+		if res.Cost != want {
+			t.Errorf("BidirectionalBFS(%v) found a path with cost %v; want %v", start, res.Cost, want)
+		}
+		checkPuzzlePath(t, start, res.Path)
+		if got, limit := res.Stats.Expanded, bfs.Stats().Expanded; want > 10 && got >= limit {
+			t.Errorf("BidirectionalBFS(%v) expanded %v states; want fewer than BFS, which expanded %v", start, got, limit)
+		}
+	}
+}
+
+func TestBidirectionalBFS_Directed(t *testing.T) {
+	// A cycle a -> b -> c -> d -> a, where the steps can only be taken in one
+	// direction.
+	next := map[string]string{"a": "b", "b": "c", "c": "d", "d": "a"}
+	prev := map[string]string{"b": "a", "c": "b", "d": "c", "a": "d"}
+	one := func(m map[string]string) func(string) iter.Seq[string] {
+		return func(s string) iter.Seq[string] {
+			return func(yield func(string) bool) { yield(m[s]) }
+		}
+	}
+	res := BidirectionalBFS("b", "a", one(next), one(prev))
+	if diff := cmp.Diff([]string{"b", "c", "d", "a"}, res.Path); diff != "" {
+		t.Errorf("BidirectionalBFS() returned unexpected path (-want +got)\n%s", diff)
+	}
+}
+
+func TestBidirectionalBFS_Trivial(t *testing.T) {
+	res := BidirectionalBFS(solved, solved, puzzle.neighbors, puzzle.neighbors)
+	if got, want := len(res.Path), 1; got != want || res.Cost != 0 {
+		t.Errorf("BidirectionalBFS(solved, solved) = path %v with cost %v; want a path of 1 state with cost 0", res.Path, res.Cost)
+	}
+}
+
+func TestBidirectionalBFS_NotFound(t *testing.T) {
+	g := mustGrid(t, `
+..#..
+..#..
+`)
+	unit, _ := maze(g)
+	res := BidirectionalBFS(asciigrid.Pos{Row: 0, Col: 0}, asciigrid.Pos{Row: 0, Col: 4}, unit, unit)
+	if res.Found() {
+		t.Errorf("BidirectionalBFS() found path %v; want none", res.Path)
+	}
+}
@@ -0,0 +1,94 @@
+package search
+
+import (
+	"iter"
+	"math"
+	"slices"
+)
+
+// PathResult is the outcome of a search that finds a single path without
+// keeping track of every state it reached, like IDAStar, BidirectionalBFS and
+// Beam.
+type PathResult[S comparable] struct {
+	// Path holds the states from the start to the goal, including both. It is
+	// nil if no goal was found.
+	Path []S
+	// Cost is the total cost of the steps in Path.
+	Cost  int
+	Stats Stats
+}
+
+// Found reports whether the search found a goal.
+func (r PathResult[S]) Found() bool {
+	return r.Path != nil
+}
+
+// IDAStar uses iterative deepening A* to find a shortest path from start to a
+// goal. It takes the same arguments as AStar, and the heuristic must be
+// consistent in the same way. A nil heuristic makes IDAStar an iterative
+// deepening depth-first search. Like for AStar, a nil isGoal means that no
+// state is a goal; the search then explores every state and finds no path.
+//
+// IDAStar only remembers the path it is currently exploring, so its memory use
+// is proportional to the length of the path rather than the number of states.
+// In return it expands states many times over: once per iteration, and once per
+// path leading to them. It works best when the heuristic is accurate and there
+// are few ways to reach the same state. PeakFrontier in the returned stats is
+// the longest path explored.
+func IDAStar[S comparable](start S, neighbors func(S) iter.Seq2[S, int], isGoal func(S) bool, heuristic func(S) int) PathResult[S] {
+	h := func(s S) int {
+		if heuristic == nil {
+			return 0
+		}
+		return heuristic(s)
+	}
+	var (
+		res  PathResult[S]
+		path = []S{start}
+		// onPath holds the states in path, so that the search doesn't go
+		// around in circles.
+		onPath = map[S]bool{start: true}
+	)
+	// dfs searches from the last state in path, which is at distance dist from
+	// the start, without going beyond limit. It returns true if it found a goal,
+	// and otherwise the lowest estimated cost above limit it came across, which
+	// is the limit for the next iteration.
+	var dfs func(dist, limit int) (bool, int)
+	dfs = func(dist, limit int) (bool, int) {
+		s := path[len(path)-1]
+		if f := dist + h(s); f > limit {
+			return false, f
+		}
+		if isGoal != nil && isGoal(s) {
+			res.Cost = dist
+			return true, 0
+		}
+		res.Stats.Expanded++
+		res.Stats.observe(len(path))
+		next := math.MaxInt
+		for n, cost := range neighbors(s) {
+			if onPath[n] {
+				continue
+			}
+			path = append(path, n)
+			onPath[n] = true
+			found, f := dfs(dist+cost, limit)
+			if found {
+				return true, 0
+			}
+			path = path[:len(path)-1]
+			delete(onPath, n)
+			next = min(next, f)
+		}
+		return false, next
+	}
+	for limit := h(start); limit != math.MaxInt; {
+		found, next := dfs(0, limit)
+		if found {
+			res.Path = slices.Clone(path)
+			break
+		}
+		limit = next
+	}
+	return res
+}
@@ -0,0 +1,45 @@
+package search
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"go.saser.se/adventofgo/asciigrid"
+)
+
+func TestIDAStar(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	for range 20 {
+		start := scramble(rng, 40)
+		want, _ := BFS(start, puzzle.neighbors, isSolved).Dist(solved)
+		res := IDAStar(start, puzzle.weightedNeighbors, isSolved, puzzle.manhattan)
+		if !res.Found() {
+			t.Errorf("IDAStar(%v) found no path; want one with cost %v", start, want)
+			continue
+		}
+		if res.Cost != want {
+			t.Errorf("IDAStar(%v) found a path with cost %v; want %v", start, res.Cost, want)
+		}
+		if got := len(res.Path) - 1; got != res.Cost {
+			t.Errorf("IDAStar(%v) found a path with %v steps; want %v", start, got, res.Cost)
+		}
+		checkPuzzlePath(t, start, res.Path)
+	}
+}
+
+func TestIDAStar_NotFound(t *testing.T) {
+	g := mustGrid(t, `
+..#..
+..#..
+`)
+	_, weighted := maze(g)
+	start := asciigrid.Pos{Row: 0, Col: 0}
+	unreachable := asciigrid.Pos{Row: 0, Col: 4}
+	res := IDAStar(start, weighted, func(p asciigrid.Pos) bool { return p == unreachable }, nil)
+	if res.Found() {
+		t.Errorf("IDAStar() found path %v; want none", res.Path)
+	}
+	if res := IDAStar(start, weighted, nil, nil); res.Found() {
+		t.Errorf("IDAStar() with nil isGoal found path %v; want none", res.Path)
+	}
+}
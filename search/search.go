@@ -0,0 +1,343 @@
+// Package search implements generic graph search algorithms: breadth-first
+// search, Dijkstra's algorithm and A*, as well as IDA*, bidirectional
+// breadth-first search and beam search for state spaces too large to keep in
+// memory.
+//
+// The graph is never built up front. Instead, states are values of any
+// comparable type, and the caller describes the graph with a function that
+// iterates over the neighbors of a state. A state is typically a position in a
+// grid, possibly together with whatever else determines where one can go next,
+// like the direction one is facing.
+//
+// BFS, Dijkstra and AStar return a Result, which holds the distance to every
+// state that was reached and all of the ways each state can be reached at that
+// distance. From it one can get distances, a single shortest path, or every
+// state on any shortest path. The other searches don't remember every state, so
+// they only return a single path in a PathResult.
+//
+// All searches report Stats, to compare strategies on the same problem.
+package search
+
+import (
+	"iter"
+	"maps"
+	"slices"
+
+	"go.saser.se/adventofgo/container/priorityqueue"
+	"go.saser.se/adventofgo/container/set"
+)
+
+// Stats describes how much work a search did, to compare different strategies
+// on the same problem.
+type Stats struct {
+	// Expanded is the number of times the search iterated over the neighbors
+	// of a state.
+	Expanded int
+	// PeakFrontier is the largest number of states the search was keeping
+	// track of at once while deciding what to expand next. What exactly that
+	// includes depends on the strategy, but it's a good proxy for memory use.
+	PeakFrontier int
+}
+
+// observe records the current frontier size.
+func (st *Stats) observe(frontier int) {
+	st.PeakFrontier = max(st.PeakFrontier, frontier)
+}
+
+// Distances records the distance from the start to each state a search
+// reaches. The searches keep distances in a map by default, which works for any
+// kind of state but means hashing every state, often many times over. When the
+// states are dense, like the positions in a grid, a Distances backed by the grid
+// itself is much faster, and leaves the distances where the caller wants them.
+type Distances[S comparable] interface {
+	// Dist returns the distance recorded for s, and whether there is one.
+	Dist(s S) (int, bool)
+	// SetDist records the distance to s.
+	SetDist(s S, dist int)
+	// All iterates over the states that have a distance, and their distances.
+	All() iter.Seq2[S, int]
+}
+
+// mapDistances is the default Distances.
+type mapDistances[S comparable] map[S]int
+
+func (m mapDistances[S]) Dist(s S) (int, bool) {
+	d, ok := m[s]
+	return d, ok
+}
+
+func (m mapDistances[S]) SetDist(s S, dist int) {
+	m[s] = dist
+}
+
+func (m mapDistances[S]) All() iter.Seq2[S, int] {
+	return maps.All(m)
+}
+
+// Result is the outcome of a search.
+type Result[S comparable] struct {
+	start     S
+	neighbors func(S) iter.Seq2[S, int]
+	dists     Distances[S]
+	// goals holds all goal states found at the shortest distance to any goal,
+	// in the order they were found.
+	goals []S
+	// preds holds all predecessors of each state. Most searches don't need it,
+	// and it's expensive to keep track of during the search, so it's computed
+	// from the distances the first time it's needed.
+	preds map[S][]S
+	stats Stats
+}
+
+func newResult[S comparable](start S, neighbors func(S) iter.Seq2[S, int], dists Distances[S]) *Result[S] {
+	dists.SetDist(start, 0)
+	return &Result[S]{
+		start:     start,
+		neighbors: neighbors,
+		dists:     dists,
+	}
+}
+
+// relax records that s can be reached at distance dist. It returns true if
+// that is shorter than the previously known distance to s, in which case s
+// needs to be (re-)visited.
+func (r *Result[S]) relax(s S, dist int) bool {
+	if d, ok := r.dists.Dist(s); ok && dist >= d {
+		return false
+	}
+	r.dists.SetDist(s, dist)
+	return true
+}
+
+// Stats returns statistics about the search.
+func (r *Result[S]) Stats() Stats {
+	return r.stats
+}
+
+// Start returns the state the search started from.
+func (r *Result[S]) Start() S {
+	return r.start
+}
+
+// Dist returns the length of the shortest path from the start to s, and
+// whether s was reached at all. If the search stopped early because it found a
+// goal, states further away than the goal may not have been reached.
+func (r *Result[S]) Dist(s S) (int, bool) {
+	return r.dists.Dist(s)
+}
+
+// All iterates over all reached states and their distances, in an undefined
+// order.
+func (r *Result[S]) All() iter.Seq2[S, int] {
+	return r.dists.All()
+}
+
+// Goal returns the first goal state the search found, and whether it found
+// one at all.
+func (r *Result[S]) Goal() (S, bool) {
+	if len(r.goals) == 0 {
+		var zero S
+		return zero, false
+	}
+	return r.goals[0], true
+}
+
+// Goals returns all goal states at the shortest distance, in the order they
+// were found. All of them are at the same distance from the start.
+func (r *Result[S]) Goals() []S {
+	return r.goals
+}
+
+// Predecessors iterates over the states that come immediately before s on a
+// shortest path to s, in an undefined order. Together the predecessors form a
+// directed acyclic graph of all shortest paths from the start. The start has no
+// predecessors.
+//
+// If the search stopped early because it found a goal, the predecessors are
+// only complete for states no further away than the goal.
+func (r *Result[S]) Predecessors(s S) iter.Seq[S] {
+	if r.preds == nil {
+		r.preds = make(map[S][]S)
+		for s, dist := range r.dists.All() {
+			for next, cost := range r.neighbors(s) {
+				if d, ok := r.dists.Dist(next); ok && next != r.start && dist+cost == d {
+					r.preds[next] = append(r.preds[next], s)
+				}
+			}
+		}
+	}
+	return slices.Values(r.preds[s])
+}
+
+// Path returns a shortest path from the start to the first goal, including
+// both. It returns nil if no goal was found.
+func (r *Result[S]) Path() []S {
+	goal, ok := r.Goal()
+	if !ok {
+		return nil
+	}
+	return r.PathTo(goal)
+}
+
+// PathTo returns a shortest path from the start to s, including both. If there
+// are several shortest paths, PathTo returns one of them. It returns nil if s
+// was not reached.
+func (r *Result[S]) PathTo(s S) []S {
+	if _, ok := r.dists.Dist(s); !ok {
+		return nil
+	}
+	path := []S{s}
+	for s != r.start {
+		for p := range r.Predecessors(s) {
+			s = p
+			break
+		}
+		path = append(path, s)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// OnShortestPaths returns the set of all states that are on any shortest path
+// from the start to any of the targets, including the start and the targets
+// themselves. Targets that were not reached are ignored.
+func (r *Result[S]) OnShortestPaths(targets ...S) set.Set[S] {
+	on := set.Of[S]()
+	var stack []S
+	for _, t := range targets {
+		if _, ok := r.dists.Dist(t); ok && on.Add(t) {
+			stack = append(stack, t)
+		}
+	}
+	for len(stack) > 0 {
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for p := range r.Predecessors(s) {
+			if on.Add(p) {
+				stack = append(stack, p)
+			}
+		}
+	}
+	return on
+}
+
+// BFS does a breadth-first search from start, where every edge has a cost of
+// 1. neighbors iterates over the states one step away from a given state.
+//
+// The search stops once it has found every goal state at the shortest distance
+// to any goal, where isGoal reports whether a state is a goal. If isGoal is nil
+// the search visits every state reachable from start, which is useful for
+// finding the distances to all of them.
+func BFS[S comparable](start S, neighbors func(S) iter.Seq[S], isGoal func(S) bool) *Result[S] {
+	return BFSInto(start, neighbors, isGoal, mapDistances[S]{})
+}
+
+// BFSInto is like BFS, but records the distances in dists instead of a map of
+// its own. dists should be empty.
+func BFSInto[S comparable](start S, neighbors func(S) iter.Seq[S], isGoal func(S) bool, dists Distances[S]) *Result[S] {
+	r := newResult(start, func(s S) iter.Seq2[S, int] {
+		return func(yield func(S, int) bool) {
+			for n := range neighbors(s) {
+				if !yield(n, 1) {
+					return
+				}
+			}
+		}
+	}, dists)
+	q := []S{start}
+	goalDist := -1
+	for len(q) > 0 {
+		s := q[0]
+		q = q[1:]
+		dist, _ := dists.Dist(s)
+		if goalDist != -1 && dist > goalDist {
+			// Everything from here on is further away than the goals.
+			break
+		}
+		if isGoal != nil && isGoal(s) {
+			r.goals = append(r.goals, s)
+			goalDist = dist
+			continue
+		}
+		if goalDist != -1 {
+			// s is as far away as the goals, so its neighbors can't be goals
+			// at the shortest distance. The rest of this level may still hold
+			// goals, though.
+			continue
+		}
+		r.stats.Expanded++
+		for n := range neighbors(s) {
+			if r.relax(n, dist+1) {
+				q = append(q, n)
+			}
+		}
+		r.stats.observe(len(q))
+	}
+	return r
+}
+
+// Dijkstra uses Dijkstra's algorithm to search from start. neighbors iterates
+// over the states one step away from a given state, together with the cost of
+// taking that step. Costs must be positive.
+//
+// The search stops once it has found every goal state at the shortest distance
+// to any goal, where isGoal reports whether a state is a goal. If isGoal is nil
+// the search visits every state reachable from start, which is useful for
+// finding the distances to all of them.
+func Dijkstra[S comparable](start S, neighbors func(S) iter.Seq2[S, int], isGoal func(S) bool) *Result[S] {
+	return AStar(start, neighbors, isGoal, nil)
+}
+
+// AStar uses the A* algorithm to search from start. It is like Dijkstra, but
+// uses heuristic to estimate the remaining cost from a state to the closest
+// goal, and visits the states that look the most promising first.
+//
+// The heuristic must be consistent: for every step from s to n with cost c,
+// heuristic(s) <= c + heuristic(n), and heuristic(goal) must be 0. Otherwise
+// the returned distances may be wrong. The Manhattan distance to the goal is
+// consistent for grids where every step costs at least 1. A nil heuristic is
+// the same as one that always returns 0, which makes AStar the same as
+// Dijkstra.
+func AStar[S comparable](start S, neighbors func(S) iter.Seq2[S, int], isGoal func(S) bool, heuristic func(S) int) *Result[S] {
+	type item struct {
+		State S
+		Dist  int
+		// Priority is Dist plus the heuristic's estimate of the remaining cost.
+		Priority int
+	}
+	h := func(s S) int {
+		if heuristic == nil {
+			return 0
+		}
+		return heuristic(s)
+	}
+	r := newResult(start, neighbors, mapDistances[S]{})
+	pq := priorityqueue.NewFunc(func(x, y item) bool { return x.Priority < y.Priority })
+	pq.Push(item{State: start, Dist: 0, Priority: h(start)})
+	goalDist := -1
+	for pq.Len() > 0 {
+		it := pq.Pop()
+		if goalDist != -1 && it.Priority > goalDist {
+			break
+		}
+		if d, _ := r.dists.Dist(it.State); it.Dist > d {
+			// A shorter path to this state was found after this item was
+			// pushed, so the state has already been visited.
+			continue
+		}
+		if isGoal != nil && isGoal(it.State) {
+			r.goals = append(r.goals, it.State)
+			goalDist = it.Dist
+			continue
+		}
+		r.stats.Expanded++
+		for n, cost := range neighbors(it.State) {
+			dist := it.Dist + cost
+			if r.relax(n, dist) {
+				pq.Push(item{State: n, Dist: dist, Priority: dist + h(n)})
+			}
+		}
+		r.stats.observe(pq.Len())
+	}
+	return r
+}
@@ -0,0 +1,363 @@
+package search
+
+import (
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.saser.se/adventofgo/asciigrid"
+)
+
+// maze returns functions describing the open cells in g as a graph, for use
+// with the searches.
+func maze(g *asciigrid.Grid) (unit func(asciigrid.Pos) iter.Seq[asciigrid.Pos], weighted func(asciigrid.Pos) iter.Seq2[asciigrid.Pos, int]) {
+	unit = func(p asciigrid.Pos) iter.Seq[asciigrid.Pos] {
+		return func(yield func(asciigrid.Pos) bool) {
+			for _, n := range g.Neighbors4(p) {
+				if g.Get(n) != '#' && !yield(n) {
+					return
+				}
+			}
+		}
+	}
+	weighted = func(p asciigrid.Pos) iter.Seq2[asciigrid.Pos, int] {
+		return func(yield func(asciigrid.Pos, int) bool) {
+			for n := range unit(p) {
+				if !yield(n, 1) {
+					return
+				}
+			}
+		}
+	}
+	return unit, weighted
+}
+
+func manhattan(p, q asciigrid.Pos) int {
+	return max(p.Row-q.Row, q.Row-p.Row) + max(p.Col-q.Col, q.Col-p.Col)
+}
+
+func mustGrid(t *testing.T, s string) *asciigrid.Grid {
+	t.Helper()
+	g, err := asciigrid.New(strings.TrimSpace(s))
+	if err != nil {
+		t.Fatalf("asciigrid.New() err = %v", err)
+	}
+	return g
+}
+
+// gridDistances stores distances in a grid, where -1 means unreached.
+type gridDistances struct {
+	g *asciigrid.GridOf[int]
+}
+
+func (d gridDistances) Dist(p asciigrid.Pos) (int, bool) {
+	dist := d.g.Get(p)
+	return dist, dist != -1
+}
+
+func (d gridDistances) SetDist(p asciigrid.Pos, dist int) {
+	d.g.Set(p, dist)
+}
+
+func (d gridDistances) All() iter.Seq2[asciigrid.Pos, int] {
+	return func(yield func(asciigrid.Pos, int) bool) {
+		for p, dist := range d.g.All() {
+			if dist != -1 && !yield(p, dist) {
+				return
+			}
+		}
+	}
+}
+
+func TestSearch_Maze(t *testing.T) {
+	g := mustGrid(t, `
+S...#
+.##.#
+.##..
+....E
+`)
+	start := asciigrid.Pos{Row: 0, Col: 0}
+	end := asciigrid.Pos{Row: 3, Col: 4}
+	isEnd := func(p asciigrid.Pos) bool { return p == end }
+	unit, weighted := maze(g)
+	for _, tt := range []struct {
+		name string
+		r    *Result[asciigrid.Pos]
+	}{
+		{name: "BFS", r: BFS(start, unit, isEnd)},
+		{name: "BFSInto", r: BFSInto(start, unit, isEnd, gridDistances{asciigrid.MakeLike(g, -1)})},
+		{name: "Dijkstra", r: Dijkstra(start, weighted, isEnd)},
+		{name: "AStar", r: AStar(start, weighted, isEnd, func(p asciigrid.Pos) int { return manhattan(p, end) })},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, ok := tt.r.Goal(); !ok || got != end {
+				t.Errorf("Goal() = %v, %v; want %v, true", got, ok, end)
+			}
+			if got, ok := tt.r.Dist(end); !ok || got != 7 {
+				t.Errorf("Dist(%v) = %v, %v; want 7, true", end, got, ok)
+			}
+			path := tt.r.Path()
+			if got, want := len(path), 8; got != want {
+				t.Fatalf("len(Path()) = %v; want %v (path: %v)", got, want, path)
+			}
+			if path[0] != start || path[len(path)-1] != end {
+				t.Errorf("Path() = %v; want a path from %v to %v", path, start, end)
+			}
+			for i := 1; i < len(path); i++ {
+				if manhattan(path[i-1], path[i]) != 1 || g.Get(path[i]) == '#' {
+					t.Errorf("Path() = %v; step %v -> %v is invalid", path, path[i-1], path[i])
+				}
+			}
+			// The shortest paths go around either side of the wall in the
+			// middle, and together cover these cells.
+			want := mustGrid(t, `
+OOOO.
+O..O.
+O..OO
+OOOOO
+`)
+			got := asciigrid.MakeLike(g, byte('.'))
+			for p := range tt.r.OnShortestPaths(end) {
+				got.Set(p, 'O')
+			}
+			if diff := cmp.Diff(want.String(), got.String()); diff != "" {
+				t.Errorf("OnShortestPaths(%v) returned unexpected states (-want +got)\n%s", end, diff)
+			}
+		})
+	}
+}
+
+func TestSearch_NoGoal(t *testing.T) {
+	g := mustGrid(t, `
+..#..
+..#..
+`)
+	start := asciigrid.Pos{Row: 0, Col: 0}
+	unreachable := asciigrid.Pos{Row: 0, Col: 4}
+	unit, weighted := maze(g)
+	for _, tt := range []struct {
+		name string
+		r    *Result[asciigrid.Pos]
+	}{
+		{name: "BFS", r: BFS(start, unit, func(p asciigrid.Pos) bool { return p == unreachable })},
+		{name: "BFSInto", r: BFSInto(start, unit, func(p asciigrid.Pos) bool { return p == unreachable }, gridDistances{asciigrid.MakeLike(g, -1)})},
+		{name: "Dijkstra", r: Dijkstra(start, weighted, func(p asciigrid.Pos) bool { return p == unreachable })},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, ok := tt.r.Goal(); ok {
+				t.Errorf("Goal() = %v, true; want false", got)
+			}
+			if got := tt.r.Path(); got != nil {
+				t.Errorf("Path() = %v; want nil", got)
+			}
+			if got, ok := tt.r.Dist(unreachable); ok {
+				t.Errorf("Dist(%v) = %v, true; want false", unreachable, got)
+			}
+			n := 0
+			for range tt.r.All() {
+				n++
+			}
+			if got, want := n, 4; got != want {
+				t.Errorf("All() yielded %v states; want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestDijkstra_Weighted(t *testing.T) {
+	// A small graph with two shortest paths from a to e, both with cost 6:
+	//
+	//	a -1-> b -5-> e
+	//	a -4-> c -2-> e
+	//	a -1-> d -9-> e
+	graph := map[string]map[string]int{
+		"a": {"b": 1, "c": 4, "d": 1},
+		"b": {"e": 5},
+		"c": {"e": 2},
+		"d": {"e": 9},
+	}
+	neighbors := func(s string) iter.Seq2[string, int] {
+		return func(yield func(string, int) bool) {
+			for n, cost := range graph[s] {
+				if !yield(n, cost) {
+					return
+				}
+			}
+		}
+	}
+	r := Dijkstra("a", neighbors, func(s string) bool { return s == "e" })
+	if got, ok := r.Dist("e"); !ok || got != 6 {
+		t.Errorf("Dist(e) = %v, %v; want 6, true", got, ok)
+	}
+	preds := slices.Sorted(r.Predecessors("e"))
+	if diff := cmp.Diff([]string{"b", "c"}, preds); diff != "" {
+		t.Errorf("Predecessors(e) returned unexpected states (-want +got)\n%s", diff)
+	}
+	on := slices.Sorted(r.OnShortestPaths("e").All())
+	if diff := cmp.Diff([]string{"a", "b", "c", "e"}, on); diff != "" {
+		t.Errorf("OnShortestPaths(e) returned unexpected states (-want +got)\n%s", diff)
+	}
+	if got := r.Predecessors("a"); slices.Collect(got) != nil {
+		t.Errorf("Predecessors(a) = %v; want none", slices.Collect(got))
+	}
+}
+
+func TestSearch_SeveralGoals(t *testing.T) {
+	// 0 has three neighbors at distance 1, of which 1 and 3 are goals, and the
+	// goal 4 is further away.
+	graph := map[int][]int{
+		0: {1, 2, 3},
+		2: {4},
+	}
+	unit := func(s int) iter.Seq[int] { return slices.Values(graph[s]) }
+	weighted := func(s int) iter.Seq2[int, int] {
+		return func(yield func(int, int) bool) {
+			for _, n := range graph[s] {
+				if !yield(n, 1) {
+					return
+				}
+			}
+		}
+	}
+	isGoal := func(s int) bool { return s == 1 || s == 3 || s == 4 }
+	for name, r := range map[string]*Result[int]{
+		"BFS":      BFS(0, unit, isGoal),
+		"Dijkstra": Dijkstra(0, weighted, isGoal),
+		"AStar":    AStar(0, weighted, isGoal, func(int) int { return 0 }),
+	} {
+		if diff := cmp.Diff([]int{1, 3}, slices.Sorted(slices.Values(r.Goals()))); diff != "" {
+			t.Errorf("%s: Goals() returned unexpected states (-want +got)\n%s", name, diff)
+		}
+		on := slices.Sorted(r.OnShortestPaths(r.Goals()...).All())
+		if diff := cmp.Diff([]int{0, 1, 3}, on); diff != "" {
+			t.Errorf("%s: OnShortestPaths(Goals()...) returned unexpected states (-want +got)\n%s", name, diff)
+		}
+	}
+}
+
+// TestSearch_Random checks that all searches agree on the distances in random
+// mazes.
+func TestSearch_Random(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	for range 50 {
+		rows, cols := 1+rng.IntN(20), 1+rng.IntN(20)
+		g := asciigrid.Make(rows, cols, byte('.'))
+		for p := range g.Bounds().All() {
+			if rng.IntN(3) == 0 {
+				g.Set(p, '#')
+			}
+		}
+		start := asciigrid.Pos{Row: 0, Col: 0}
+		end := asciigrid.Pos{Row: rows - 1, Col: cols - 1}
+		g.Set(start, '.')
+		g.Set(end, '.')
+		unit, weighted := maze(g)
+
+		all := BFS(start, unit, nil)
+		wantDist, wantOK := all.Dist(end)
+		for name, r := range map[string]*Result[asciigrid.Pos]{
+			"BFS":      BFS(start, unit, func(p asciigrid.Pos) bool { return p == end }),
+			"Dijkstra": Dijkstra(start, weighted, func(p asciigrid.Pos) bool { return p == end }),
+			"AStar":    AStar(start, weighted, func(p asciigrid.Pos) bool { return p == end }, func(p asciigrid.Pos) int { return manhattan(p, end) }),
+		} {
+			gotDist, gotOK := r.Dist(end)
+			if gotDist != wantDist || gotOK != wantOK {
+				t.Errorf("%s: Dist(%v) = %v, %v; want %v, %v in grid\n%v", name, end, gotDist, gotOK, wantDist, wantOK, g)
+			}
+			if got, want := len(r.Path()), len(all.PathTo(end)); got != want {
+				t.Errorf("%s: len(Path()) = %v; want %v in grid\n%v", name, got, want, g)
+			}
+			if got, want := r.OnShortestPaths(end).Len(), all.OnShortestPaths(end).Len(); got != want {
+				t.Errorf("%s: OnShortestPaths(%v).Len() = %v; want %v in grid\n%v", name, end, got, want, g)
+			}
+		}
+		// Without a goal, Dijkstra finds the same distances as BFS everywhere.
+		d := Dijkstra(start, weighted, nil)
+		for p, want := range all.All() {
+			if got, ok := d.Dist(p); !ok || got != want {
+				t.Errorf("Dijkstra with no goal: Dist(%v) = %v, %v; want %v, true in grid\n%v", p, got, ok, want, g)
+			}
+		}
+	}
+}
+
+// puzzle is a state of the 8-puzzle: a 3x3 board of the tiles 1 to 8 and a
+// blank, 0. A step slides a tile next to the blank into it. It makes for a state
+// space that's small enough to search exhaustively in tests, but large enough
+// for the strategies to differ.
+type puzzle [9]byte
+
+var solved = puzzle{1, 2, 3, 4, 5, 6, 7, 8, 0}
+
+func (p puzzle) neighbors() iter.Seq[puzzle] {
+	return func(yield func(puzzle) bool) {
+		blank := slices.Index(p[:], 0)
+		row, col := blank/3, blank%3
+		for _, d := range []struct{ dRow, dCol int }{{-1, 0}, {0, 1}, {1, 0}, {0, -1}} {
+			r, c := row+d.dRow, col+d.dCol
+			if r < 0 || r >= 3 || c < 0 || c >= 3 {
+				continue
+			}
+			n := p
+			n[blank], n[r*3+c] = n[r*3+c], n[blank]
+			if !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+func (p puzzle) weightedNeighbors() iter.Seq2[puzzle, int] {
+	return func(yield func(puzzle, int) bool) {
+		for n := range p.neighbors() {
+			if !yield(n, 1) {
+				return
+			}
+		}
+	}
+}
+
+// manhattan returns the sum of the distances of the tiles from where they are
+// in the solved puzzle, which is a consistent heuristic.
+func (p puzzle) manhattan() int {
+	sum := 0
+	for i, tile := range p {
+		if tile == 0 {
+			continue
+		}
+		want := int(tile) - 1
+		sum += max(i/3-want/3, want/3-i/3) + max(i%3-want%3, want%3-i%3)
+	}
+	return sum
+}
+
+func isSolved(p puzzle) bool { return p == solved }
+
+// scramble returns a puzzle that is solvable, by making random moves from the
+// solved puzzle.
+func scramble(rng *rand.Rand, moves int) puzzle {
+	p := solved
+	for range moves {
+		ns := slices.Collect(p.neighbors())
+		p = ns[rng.IntN(len(ns))]
+	}
+	return p
+}
+
+// checkPuzzlePath checks that path is a valid path from start to the solved
+// puzzle.
+func checkPuzzlePath(t *testing.T, start puzzle, path []puzzle) {
+	t.Helper()
+	if len(path) == 0 || path[0] != start || path[len(path)-1] != solved {
+		t.Errorf("path %v doesn't go from %v to %v", path, start, solved)
+		return
+	}
+	for i := 1; i < len(path); i++ {
+		if !slices.Contains(slices.Collect(path[i-1].neighbors()), path[i]) {
+			t.Errorf("path has an invalid step from %v to %v", path[i-1], path[i])
+		}
+	}
+}
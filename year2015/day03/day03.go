@@ -2,31 +2,35 @@ package day03
 
 import (
 	"fmt"
+	"unicode"
 
-	"go.saser.se/adventofgo/geometry"
+	"go.saser.se/adventofgo/asciigrid"
 )
 
 func solve(input string, part int) (string, error) {
-	presents := make(map[geometry.Pos2]struct{})
+	// presents holds the number of presents delivered to each house.
+	presents := asciigrid.NewSparse(0)
 	// In part 1 there is one santa (Santa)
 	// In part 2 there are two (Santa and Robo-Santa).
-	santas := make([]geometry.Pos2, part)
-	presents[geometry.Pos2{X: 0, Y: 0}] = struct{}{}
-	for i, r := range input {
-		n := i % len(santas)
-		switch r {
-		case '^':
-			santas[n].Y++
-		case '>':
-			santas[n].X++
-		case 'v':
-			santas[n].Y--
-		case '<':
-			santas[n].X--
+	santas := make([]asciigrid.Pos, part)
+	presents.Set(asciigrid.Pos{Row: 0, Col: 0}, 1)
+	// moves counts the moves so far, which take turns between the santas.
+	moves := 0
+	for i := range len(input) {
+		if unicode.IsSpace(rune(input[i])) {
+			// Like a trailing newline.
+			continue
 		}
-		presents[santas[n]] = struct{}{}
+		d, err := asciigrid.Arrows.ParseDirection(input[i : i+1])
+		if err != nil {
+			return "", fmt.Errorf("move %d: %v", moves+1, err)
+		}
+		n := moves % len(santas)
+		santas[n] = santas[n].Step(d)
+		presents.Set(santas[n], presents.Get(santas[n])+1)
+		moves++
 	}
-	return fmt.Sprint(len(presents)), nil
+	return fmt.Sprint(presents.Len()), nil
 }
 
 func Part1(input string) (string, error) {
@@ -14,6 +14,40 @@ func TestPart2(t *testing.T) {
 	aoctest.Test(t, 2015, 3, 2, Part2)
 }
 
+func TestWhitespace(t *testing.T) {
+	// The examples from the puzzle, with whitespace like in a file. Whitespace
+	// doesn't count as a move, so it doesn't change whose turn it is.
+	for _, tt := range []struct {
+		part  func(string) (string, error)
+		input string
+		want  string
+	}{
+		{part: Part1, input: "^>v<\n", want: "4"},
+		{part: Part2, input: "^v^v^v^v^v\n", want: "11"},
+		{part: Part2, input: "^v^v\n^v^v^v", want: "11"},
+		{part: Part2, input: "^ v", want: "3"},
+	} {
+		got, err := tt.part(tt.input)
+		if err != nil {
+			t.Errorf("input %q: err = %v; want nil", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("input %q: got %q; want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestInvalidMove(t *testing.T) {
+	// Only arrows are moves, even though other puzzles use letters for
+	// directions.
+	for _, input := range []string{"^U", "^>N", "x"} {
+		if got, err := Part1(input); err == nil {
+			t.Errorf("Part1(%q) = %q, nil; want error", input, got)
+		}
+	}
+}
+
 func BenchmarkPart1(b *testing.B) {
 	aoctest.Benchmark(b, 2015, 3, 1, Part1)
 }
@@ -19,7 +19,7 @@ const (
 
 type instruction struct {
 	Operation op
-	From, To  geometry.Pos2
+	Lights    geometry.Rect
 }
 
 var instructionRE = regexp.MustCompile(`(turn on|toggle|turn off) (\d+),(\d+) through (\d+),(\d+)`)
@@ -38,23 +38,27 @@ func parse(line string) (instruction, error) {
 	case "turn off":
 		in.Operation = opTurnOff
 	}
-	var err error
-	in.From.X, err = strconv.Atoi(matches[2])
+	var (
+		from, to geometry.Pos2
+		err      error
+	)
+	from.X, err = strconv.Atoi(matches[2])
 	if err != nil {
 		return instruction{}, fmt.Errorf("invalid line %q: parse from's X coordinate: %v", line, err)
 	}
-	in.From.Y, err = strconv.Atoi(matches[3])
+	from.Y, err = strconv.Atoi(matches[3])
 	if err != nil {
 		return instruction{}, fmt.Errorf("invalid line %q: parse from's Y coordinate: %v", line, err)
 	}
-	in.To.X, err = strconv.Atoi(matches[4])
+	to.X, err = strconv.Atoi(matches[4])
 	if err != nil {
 		return instruction{}, fmt.Errorf("invalid line %q: parse to's X coordinate: %v", line, err)
 	}
-	in.To.Y, err = strconv.Atoi(matches[5])
+	to.Y, err = strconv.Atoi(matches[5])
 	if err != nil {
 		return instruction{}, fmt.Errorf("invalid line %q: parse to's Y coordinate: %v", line, err)
 	}
+	in.Lights = geometry.RectOf(from, to)
 	return in, nil
 }
 
@@ -63,9 +67,49 @@ const (
 	size = side * side
 )
 
-func apply(in instruction, l lights) {
-	for x := in.From.X; x <= in.To.X; x++ {
-		for y := in.From.Y; y <= in.To.Y; y++ {
+// litArea returns the number of lights that are on after following the
+// instructions with on/off lights. Instead of going light by light, it keeps
+// track of the lit lights as a list of disjoint rectangles.
+func litArea(instructions []instruction) int {
+	var lit []geometry.Rect
+	// without returns the pieces of the rectangles in rs that are not in r.
+	without := func(rs []geometry.Rect, r geometry.Rect) []geometry.Rect {
+		var pieces []geometry.Rect
+		for _, s := range rs {
+			pieces = append(pieces, s.Sub(r)...)
+		}
+		return pieces
+	}
+	for _, in := range instructions {
+		switch in.Operation {
+		case opTurnOn:
+			lit = append(without(lit, in.Lights), in.Lights)
+		case opToggle:
+			// The lights in the rectangle that were off are the ones that
+			// are left after cutting away the ones that were on.
+			unlit := []geometry.Rect{in.Lights}
+			for _, r := range lit {
+				if r.Intersect(in.Lights).Empty() {
+					continue
+				}
+				unlit = without(unlit, r)
+			}
+			lit = append(without(lit, in.Lights), unlit...)
+		case opTurnOff:
+			lit = without(lit, in.Lights)
+		}
+	}
+	area := 0
+	for _, r := range lit {
+		area += r.Area()
+	}
+	return area
+}
+
+func apply(in instruction, l *numericLights) {
+	r := in.Lights
+	for x := r.X.Start; x < r.X.End; x++ {
+		for y := r.Y.Start; y < r.Y.End; y++ {
 			i := side*y + x
 			switch in.Operation {
 			case opTurnOn:
@@ -79,28 +123,6 @@ func apply(in instruction, l lights) {
 	}
 }
 
-type lights interface {
-	TurnOn(i int)
-	Toggle(i int)
-	TurnOff(i int)
-	Brightness() int
-}
-
-type binaryLights [size]bool
-
-func (b *binaryLights) TurnOn(i int)  { b[i] = true }
-func (b *binaryLights) Toggle(i int)  { b[i] = !b[i] }
-func (b *binaryLights) TurnOff(i int) { b[i] = false }
-func (b *binaryLights) Brightness() int {
-	n := 0
-	for _, l := range *b {
-		if l {
-			n++
-		}
-	}
-	return n
-}
-
 type numericLights [size]int
 
 func (b *numericLights) TurnOn(i int)  { b[i] += 1 }
@@ -114,29 +136,34 @@ func (b *numericLights) Brightness() int {
 	return n
 }
 
-func max(a, b int) int {
-	if a > b {
-		return a
-	}
-	return b
-}
-
-func solve(input string, part int) (string, error) {
-	l := []lights{&binaryLights{}, &numericLights{}}[part-1]
+func parseInput(input string) ([]instruction, error) {
+	var instructions []instruction
 	for line := range strings.SplitSeq(input, "\n") {
 		in, err := parse(line)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
-		apply(in, l)
+		instructions = append(instructions, in)
 	}
-	return fmt.Sprint(l.Brightness()), nil
+	return instructions, nil
 }
 
 func Part1(input string) (string, error) {
-	return solve(input, 1)
+	instructions, err := parseInput(input)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprint(litArea(instructions)), nil
 }
 
 func Part2(input string) (string, error) {
-	return solve(input, 2)
+	instructions, err := parseInput(input)
+	if err != nil {
+		return "", err
+	}
+	l := &numericLights{}
+	for _, in := range instructions {
+		apply(in, l)
+	}
+	return fmt.Sprint(l.Brightness()), nil
 }
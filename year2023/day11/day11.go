@@ -22,9 +22,8 @@ func parse(input string, expansionFactor int64) (*image, error) {
 		expansionFactor: expansionFactor,
 	}
 	for row := 0; row < g.NRows(); row++ {
-		it := g.Row(row)
 		hasGalaxy := false
-		for pos, tile, ok := it.Next(); ok; pos, tile, ok = it.Next() {
+		for pos, tile := range g.Ray(asciigrid.Pos{Row: row, Col: 0}, asciigrid.Right) {
 			if tile == '#' {
 				hasGalaxy = true
 				img.galaxies = append(img.galaxies, pos)
@@ -35,9 +34,8 @@ func parse(input string, expansionFactor int64) (*image, error) {
 		}
 	}
 	for col := 0; col < g.NCols(); col++ {
-		it := g.Col(col)
 		hasGalaxy := false
-		for _, tile, ok := it.Next(); ok; _, tile, ok = it.Next() {
+		for _, tile := range g.Ray(asciigrid.Pos{Row: 0, Col: col}, asciigrid.Down) {
 			if tile == '#' {
 				hasGalaxy = true
 				// We don't append to img.galaxies here -- we already saw this
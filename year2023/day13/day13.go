@@ -2,65 +2,19 @@ package day13
 
 import (
 	"fmt"
-	"strings"
-)
-
-type pattern [][]bool
-
-func parsePattern(fragment string) pattern {
-	var g pattern
-	for line := range strings.SplitSeq(fragment, "\n") {
-		row := make([]bool, len(line))
-		for i, c := range line {
-			row[i] = c == '#'
-		}
-		g = append(g, row)
-	}
-	return g
-}
-
-func parse(input string) []pattern {
-	var ps []pattern
-	for fragment := range strings.SplitSeq(input, "\n\n") {
-		ps = append(ps, parsePattern(fragment))
-	}
-	return ps
-}
 
-func mirroredOverRow(g pattern, row int, fixSmudge bool) bool {
-	hasFixedSmudge := false
-	for j, k := row, row+1; j >= 0 && k < len(g); j, k = j-1, k+1 {
-		lo, hi := g[j], g[k]
-		diff := 0
-		for col := range lo {
-			if lo[col] != hi[col] {
-				diff++
-				if !fixSmudge || diff > 1 {
-					return false
-				}
-			}
-		}
-		if diff == 0 {
-			continue
-		}
-		if diff == 1 && !hasFixedSmudge {
-			hasFixedSmudge = true
-			continue
-		}
-		return false
-	}
-	if fixSmudge {
-		return hasFixedSmudge
-	}
-	return true
-}
+	"go.saser.se/adventofgo/asciigrid"
+)
 
-func mirroredOverColumn(g pattern, col int, fixSmudge bool) bool {
+// mirroredOverRow reports whether g is mirrored over the line between row and
+// row+1. Mirroring over columns can be checked by calling mirroredOverRow on
+// the transposed grid.
+func mirroredOverRow(g *asciigrid.Grid, row int, fixSmudge bool) bool {
 	hasFixedSmudge := false
-	for j, k := col, col+1; j >= 0 && k < len(g[0]); j, k = j-1, k+1 {
+	for j, k := row, row+1; j >= 0 && k < g.NRows(); j, k = j-1, k+1 {
 		diff := 0
-		for row := range g {
-			if g[row][j] != g[row][k] {
+		for col := 0; col < g.NCols(); col++ {
+			if g.Get(asciigrid.Pos{Row: j, Col: col}) != g.Get(asciigrid.Pos{Row: k, Col: col}) {
 				diff++
 				if !fixSmudge || diff > 1 {
 					return false
@@ -84,18 +38,22 @@ func mirroredOverColumn(g pattern, col int, fixSmudge bool) bool {
 
 func solve(input string, part int) (string, error) {
 	fixSmudge := part == 2
-	patterns := parse(input)
+	patterns, err := asciigrid.ParseBlocks(input)
+	if err != nil {
+		return "", fmt.Errorf("parse input as grids: %v", err)
+	}
 	sum := 0
 	for _, g := range patterns {
-		for row := 0; row < len(g)-1; row++ {
+		for row := 0; row < g.NRows()-1; row++ {
 			if mirroredOverRow(g, row, fixSmudge) {
 				// The row used for summation is 1-indexed, not 0-indexed.
 				sum += 100 * (row + 1)
 				break // remove?
 			}
 		}
-		for col := 0; col < len(g[0])-1; col++ {
-			if mirroredOverColumn(g, col, fixSmudge) {
+		t := g.Transpose()
+		for col := 0; col < t.NRows()-1; col++ {
+			if mirroredOverRow(t, col, fixSmudge) {
 				// The column used for summation is 1-indexed, not 0-indexed.
 				sum += col + 1
 			}
@@ -27,67 +27,46 @@ func tiltNorth(g *asciigrid.Grid) {
 	}
 }
 
-func tiltEast(g *asciigrid.Grid) {
-	for col := g.NCols() - 2; col >= 0; col-- {
-		for row := 0; row < g.NRows(); row++ {
-			moveRock(g, asciigrid.Pos{Row: row, Col: col}, asciigrid.Right)
-		}
-	}
-}
-
-func tiltSouth(g *asciigrid.Grid) {
-	for row := g.NRows() - 2; row >= 0; row-- {
-		for col := 0; col < g.NCols(); col++ {
-			moveRock(g, asciigrid.Pos{Row: row, Col: col}, asciigrid.Down)
-		}
-	}
-}
-
-func tiltWest(g *asciigrid.Grid) {
-	for col := 1; col < g.NCols(); col++ {
-		for row := 0; row < g.NRows(); row++ {
-			moveRock(g, asciigrid.Pos{Row: row, Col: col}, asciigrid.Left)
-		}
+// spinCycle returns a new grid where the platform has been tilted north, west,
+// south and east, in that order. It does so by tilting north and then rotating
+// the platform clockwise four times, which leaves the platform in its original
+// orientation.
+func spinCycle(g *asciigrid.Grid) *asciigrid.Grid {
+	g = g.Clone()
+	for range 4 {
+		tiltNorth(g)
+		g = g.Rotate90()
 	}
+	return g
 }
 
-func spinCycle(g *asciigrid.Grid) {
-	tiltNorth(g)
-	tiltWest(g)
-	tiltSouth(g)
-	tiltEast(g)
-}
-
-func spin(g *asciigrid.Grid, n int) {
-	var seen []string
-	seen = append(seen, g.String())
+func spin(g *asciigrid.Grid, n int) *asciigrid.Grid {
+	var seen []*asciigrid.Grid
+	seen = append(seen, g)
 	idx := make(map[string]int) // grid state -> nr of spins (which is also an index into seen)
 	idx[g.String()] = 0
 	var cycleStart, cycleLen int
 	for i := 1; i <= n; i++ {
-		spinCycle(g)
+		g = spinCycle(g)
 		s := g.String()
 		if start, ok := idx[s]; ok {
 			cycleStart = start
 			cycleLen = i - start
 			break
 		}
-		seen = append(seen, s)
+		seen = append(seen, g)
 		idx[s] = i
 	}
 	final := cycleStart + ((n - cycleStart) % cycleLen)
-	s := seen[final]
-	*g = *asciigrid.MustNew(s)
+	return seen[final]
 }
 
 func totalLoad(g *asciigrid.Grid) int {
 	load := 0
-	for row := 0; row < g.NRows(); row++ {
-		f := g.NRows() - row
-		it := g.Row(row)
-		for _, tile, ok := it.Next(); ok; _, tile, ok = it.Next() {
+	for row := range g.Rows() {
+		for pos, tile := range row {
 			if tile == 'O' {
-				load += f
+				load += g.NRows() - pos.Row
 			}
 		}
 	}
@@ -102,7 +81,7 @@ func solve(input string, part int) (string, error) {
 	if part == 1 {
 		tiltNorth(g)
 	} else {
-		spin(g, 1e9)
+		g = spin(g, 1e9)
 	}
 	return fmt.Sprint(totalLoad(g)), nil
 }
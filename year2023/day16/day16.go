@@ -9,48 +9,31 @@ import (
 )
 
 type beam struct {
-	Pos asciigrid.Pos
-	Dir asciigrid.Direction
+	Index asciigrid.Index
+	Dir   asciigrid.Direction
 }
 
 func energized(g *asciigrid.Grid, b beam) int {
 	q := []beam{b}
-	state := make([]bool, g.NRows()*g.NCols())
-	seenAll := make([]bool, 4*len(state))
-	seenUp := seenAll[0*len(state) : 1*len(state)]
-	seenDown := seenAll[1*len(state) : 2*len(state)]
-	seenLeft := seenAll[2*len(state) : 3*len(state)]
-	seenRight := seenAll[3*len(state) : 4*len(state)]
+	// seen holds a bitmask for each tile of the directions that beams have
+	// passed through it in.
+	seen := asciigrid.MakeLike(g, uint16(0))
 	n := 0
 queueLoop:
 	for len(q) > 0 {
 		b := q[0]
 		q = q[1:]
 		for {
-			if !g.InBounds(b.Pos) {
+			mask := uint16(1) << b.Dir
+			s := seen.GetIndex(b.Index)
+			if s&mask != 0 {
 				break
 			}
-			var seen []bool
-			switch b.Dir {
-			case asciigrid.Up:
-				seen = seenUp
-			case asciigrid.Down:
-				seen = seenDown
-			case asciigrid.Left:
-				seen = seenLeft
-			case asciigrid.Right:
-				seen = seenRight
-			}
-			k := g.Index(b.Pos)
-			if seen[k] {
-				break
-			}
-			seen[k] = true
-			if i := g.Index(b.Pos); !state[i] {
-				state[i] = true
+			if s == 0 {
 				n++
 			}
-			switch g.Get(b.Pos) {
+			seen.SetIndex(b.Index, s|mask)
+			switch g.GetIndex(b.Index) {
 			case '/':
 				switch b.Dir {
 				case asciigrid.Up:
@@ -96,52 +79,61 @@ queueLoop:
 				case asciigrid.Up, asciigrid.Down:
 					// Nothing happens.
 				case asciigrid.Left, asciigrid.Right:
-					q = append(q,
-						beam{Pos: b.Pos.Step(asciigrid.Up), Dir: asciigrid.Up},
-						beam{Pos: b.Pos.Step(asciigrid.Down), Dir: asciigrid.Down},
-					)
+					q = split(q, g, b.Index, asciigrid.Up, asciigrid.Down)
 					continue queueLoop
 				}
 
 			case '-':
 				switch b.Dir {
 				case asciigrid.Up, asciigrid.Down:
-					q = append(q,
-						beam{Pos: b.Pos.Step(asciigrid.Left), Dir: asciigrid.Left},
-						beam{Pos: b.Pos.Step(asciigrid.Right), Dir: asciigrid.Right},
-					)
+					q = split(q, g, b.Index, asciigrid.Left, asciigrid.Right)
 					continue queueLoop
 				case asciigrid.Left, asciigrid.Right:
 					// Nothing happens.
 				}
 			}
-			b.Pos = b.Pos.Step(b.Dir)
+			next, ok := g.StepIndex(b.Index, b.Dir)
+			if !ok {
+				break
+			}
+			b.Index = next
 		}
 	}
 	return n
 }
 
+// split appends to q the beams that result from a beam being split at i into
+// the two given directions. Beams that would leave the grid are not appended.
+func split(q []beam, g *asciigrid.Grid, i asciigrid.Index, a, b asciigrid.Direction) []beam {
+	for _, dir := range []asciigrid.Direction{a, b} {
+		if next, ok := g.StepIndex(i, dir); ok {
+			q = append(q, beam{Index: next, Dir: dir})
+		}
+	}
+	return q
+}
+
 func solve(input string, part int) (string, error) {
 	g, err := asciigrid.New(input)
 	if err != nil {
 		return "", err
 	}
 	if part == 1 {
-		return fmt.Sprint(energized(g, beam{Pos: asciigrid.Pos{Row: 0, Col: 0}, Dir: asciigrid.Right})), nil
+		return fmt.Sprint(energized(g, beam{Index: g.Index(asciigrid.Pos{Row: 0, Col: 0}), Dir: asciigrid.Right})), nil
 	}
 	beams := make([]beam, 0, 2*g.NCols()+2*g.NRows())
 	// Top row facing down, and bottom row facing up.
 	for col := 0; col < 0; col++ {
 		beams = append(beams,
-			beam{Pos: asciigrid.Pos{Row: 0, Col: col}, Dir: asciigrid.Down},           // Top row.
-			beam{Pos: asciigrid.Pos{Row: g.NRows() - 1, Col: col}, Dir: asciigrid.Up}, // Bottom row.
+			beam{Index: g.Index(asciigrid.Pos{Row: 0, Col: col}), Dir: asciigrid.Down},           // Top row.
+			beam{Index: g.Index(asciigrid.Pos{Row: g.NRows() - 1, Col: col}), Dir: asciigrid.Up}, // Bottom row.
 		)
 	}
 	// Leftmost column facing right, and rightmost column facing left.
 	for row := 0; row < g.NRows(); row++ {
 		beams = append(beams,
-			beam{Pos: asciigrid.Pos{Row: row, Col: 0}, Dir: asciigrid.Right},            // Leftmost column.
-			beam{Pos: asciigrid.Pos{Row: row, Col: g.NCols() - 1}, Dir: asciigrid.Left}, // Rightmost column.
+			beam{Index: g.Index(asciigrid.Pos{Row: row, Col: 0}), Dir: asciigrid.Right},            // Leftmost column.
+			beam{Index: g.Index(asciigrid.Pos{Row: row, Col: g.NCols() - 1}), Dir: asciigrid.Left}, // Rightmost column.
 		)
 	}
 	energies := make([]int, len(beams))
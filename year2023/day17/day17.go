@@ -1,12 +1,12 @@
 package day17
 
 import (
-	"cmp"
 	"errors"
 	"fmt"
+	"iter"
 
 	"go.saser.se/adventofgo/asciigrid"
-	"go.saser.se/adventofgo/container/priorityqueue"
+	"go.saser.se/adventofgo/search"
 )
 
 type direction rune
@@ -36,13 +36,23 @@ func (d direction) Inverse() direction {
 	}
 }
 
+// crucible is the state of a crucible on its way through the city.
 type crucible struct {
 	Pos       asciigrid.Pos
-	Loss      int
 	Direction direction
 	Steps     int // Consecutive steps in Direction. Resets on changing direction. Is 0 if Direction is '-'.
 }
 
+var moves = []struct {
+	Dir   direction
+	Delta asciigrid.Pos
+}{
+	{Dir: dirUp, Delta: asciigrid.Pos{Row: -1, Col: 0}},
+	{Dir: dirDown, Delta: asciigrid.Pos{Row: +1, Col: 0}},
+	{Dir: dirLeft, Delta: asciigrid.Pos{Row: 0, Col: -1}},
+	{Dir: dirRight, Delta: asciigrid.Pos{Row: 0, Col: +1}},
+}
+
 func solve(input string, part int) (string, error) {
 	minSteps := 1
 	maxSteps := 3
@@ -56,67 +66,46 @@ func solve(input string, part int) (string, error) {
 	}
 	start := asciigrid.Pos{Row: 0, Col: 0}
 	end := asciigrid.Pos{Row: g.NRows() - 1, Col: g.NCols() - 1}
-	q := priorityqueue.NewFunc[crucible](func(x, y crucible) bool { return cmp.Less(x.Loss, y.Loss) })
-	type state struct {
-		Pos       asciigrid.Pos
-		Direction direction
-		Steps     int
-	}
-	seen := make(map[state]int) // State -> lowest seen heat loss for that state.
-	q.Push(crucible{
-		Pos:       start,
-		Loss:      0,
-		Direction: dirNone,
-		Steps:     0,
-	})
-	for q.Len() > 0 {
-		c := q.Pop()
-		if c.Pos == end {
-			return fmt.Sprint(c.Loss), nil
-		}
-		deltas := map[direction]asciigrid.Pos{
-			dirUp:    {Row: -1, Col: 0},
-			dirDown:  {Row: +1, Col: 0},
-			dirLeft:  {Row: 0, Col: -1},
-			dirRight: {Row: 0, Col: +1},
-		}
-		delete(deltas, c.Direction.Inverse())
-		if c.Steps == maxSteps {
-			delete(deltas, c.Direction)
-		}
-		for dir, delta := range deltas {
-			c2 := crucible{
-				Pos:       c.Pos,
-				Loss:      c.Loss,
-				Direction: dir,
-				Steps:     0,
+	neighbors := func(c crucible) iter.Seq2[crucible, int] {
+		return func(yield func(crucible, int) bool) {
+			for _, m := range moves {
+				dir, delta := m.Dir, m.Delta
+				if dir == c.Direction.Inverse() || (dir == c.Direction && c.Steps == maxSteps) {
+					continue
+				}
+				c2 := crucible{
+					Pos:       c.Pos,
+					Direction: dir,
+					Steps:     0,
+				}
+				if dir == c.Direction {
+					c2.Steps = c.Steps
+				}
+				rem := max(minSteps-c2.Steps, 1)
+				if p := (asciigrid.Pos{Row: c2.Pos.Row + delta.Row*rem, Col: c2.Pos.Col + delta.Col*rem}); !g.InBounds(p) {
+					continue
+				}
+				loss := 0
+				for i := 0; i < rem; i++ {
+					c2.Pos.Row += delta.Row
+					c2.Pos.Col += delta.Col
+					loss += int(g.Get(c2.Pos)) - '0'
+					c2.Steps++
+				}
+				if !yield(c2, loss) {
+					return
+				}
 			}
-			if dir == c.Direction {
-				c2.Steps = c.Steps
-			}
-			rem := max(minSteps-c2.Steps, 1)
-			if p := (asciigrid.Pos{Row: c2.Pos.Row + delta.Row*rem, Col: c2.Pos.Col + delta.Col*rem}); !g.InBounds(p) {
-				continue
-			}
-			for i := 0; i < rem; i++ {
-				c2.Pos.Row += delta.Row
-				c2.Pos.Col += delta.Col
-				c2.Loss += int(g.Get(c2.Pos)) - '0'
-				c2.Steps++
-			}
-			s := state{
-				Pos:       c2.Pos,
-				Direction: c2.Direction,
-				Steps:     c2.Steps,
-			}
-			if _, ok := seen[s]; ok {
-				continue
-			}
-			seen[s] = c2.Loss
-			q.Push(c2)
 		}
 	}
-	return "", errors.New("no solution found")
+	isEnd := func(c crucible) bool { return c.Pos == end }
+	r := search.Dijkstra(crucible{Pos: start, Direction: dirNone}, neighbors, isEnd)
+	goal, ok := r.Goal()
+	if !ok {
+		return "", errors.New("no solution found")
+	}
+	loss, _ := r.Dist(goal)
+	return fmt.Sprint(loss), nil
 }
 
 func Part1(input string) (string, error) {
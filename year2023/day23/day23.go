@@ -7,21 +7,6 @@ import (
 	"go.saser.se/adventofgo/asciigrid"
 )
 
-func uphill(dir asciigrid.Direction) byte {
-	switch dir {
-	case asciigrid.Up:
-		return 'v'
-	case asciigrid.Down:
-		return '^'
-	case asciigrid.Left:
-		return '>'
-	case asciigrid.Right:
-		return '<'
-	default:
-		panic("unreachable")
-	}
-}
-
 type edge struct {
 	Src, Dst asciigrid.Index
 	Weight   int
@@ -39,155 +24,37 @@ func newGraph2(input string, isDAG bool) (*graph, error) {
 	if err != nil {
 		return nil, err
 	}
-	junctions := findJunctions(grid)
-	start := junctions[0]
-	end := junctions[len(junctions)-1]
-	edges := findEdges(grid, start, end)
+	canStep := asciigrid.Slopes(grid, '#')
 	if !isDAG {
-		for _, e := range edges {
-			edges = append(edges, edge{
-				Src:    e.Dst,
-				Dst:    e.Src,
-				Weight: e.Weight,
-			})
+		// Without slopes, the only thing stopping us is the forest.
+		canStep = func(from asciigrid.Pos, d asciigrid.Direction) bool {
+			return grid.Get(from) != '#' && grid.Get(from.Step(d)) != '#'
 		}
 	}
+	// The start and the end are in the top and bottom rows.
+	isStartOrEnd := func(p asciigrid.Pos) bool { return p.Row == 0 || p.Row == grid.NRows()-1 }
+	compressed := grid.Compress(canStep, isStartOrEnd)
+	var junctions []asciigrid.Index
+	for _, p := range compressed.Nodes() {
+		junctions = append(junctions, grid.Index(p))
+	}
 	g := &graph{
 		junctions: junctions,
-		start:     start,
-		end:       end,
-		edges:     make([][]edge, grid.NRows()*grid.NCols()),
+		start:     junctions[0],
+		end:       junctions[len(junctions)-1],
+		edges:     make([][]edge, grid.NIndices()),
 		isDAG:     isDAG,
 	}
-	for _, e := range edges {
-		g.edges[e.Src] = append(g.edges[e.Src], e)
-	}
-	return g, nil
-}
-
-func findJunctions(grid *asciigrid.Grid) []asciigrid.Index {
-	var junctions []asciigrid.Index
-	for row := 0; row < grid.NRows(); row++ {
-		it := grid.Row(row)
-		for pos, tile, ok := it.Next(); ok; pos, tile, ok = it.Next() {
-			if tile != '.' {
-				continue
-			}
-			isJunction := false
-			if row == 0 || row == grid.NRows()-1 {
-				isJunction = true
-			} else {
-				slopes := 0
-				for _, dir := range []asciigrid.Direction{
-					asciigrid.Up,
-					asciigrid.Down,
-					asciigrid.Left,
-					asciigrid.Right,
-				} {
-					switch grid.Get(pos.Step(dir)) {
-					case '^', 'v', '<', '>':
-						slopes++
-					}
-					if slopes >= 2 {
-						isJunction = true
-						break
-					}
-				}
-			}
-			if isJunction {
-				grid.Set(pos, 'X')
-				junctions = append(junctions, grid.Index(pos))
-			}
-		}
-	}
-	return junctions
-}
-
-func findEdgesRecursive(acc *[]edge, grid *asciigrid.Grid, visited map[asciigrid.Index]bool, src, end asciigrid.Index) {
-	if src == end {
-		return
-	}
-	if visited[src] {
-		return
-	}
-	visited[src] = true
-	var next []asciigrid.Index
-	for _, dir := range []asciigrid.Direction{
-		asciigrid.Up,
-		asciigrid.Down,
-		asciigrid.Left,
-		asciigrid.Right,
-	} {
-		n := grid.Pos(src).Step(dir)
-		if !grid.InBounds(n) {
-			continue
-		}
-		if c := grid.Get(n); c == '#' || c == uphill(dir) {
-			continue
-		}
-		dst, steps := nextJunction2(grid, src, dir)
-		next = append(next, dst)
-		*acc = append(*acc, edge{
-			Src:    src,
-			Dst:    dst,
-			Weight: steps,
-		})
-	}
-	for _, dst := range next {
-		findEdgesRecursive(acc, grid, visited, dst, end)
-	}
-}
-
-func findEdges(grid *asciigrid.Grid, start, end asciigrid.Index) []edge {
-	var edges []edge
-	findEdgesRecursive(&edges, grid, make(map[asciigrid.Index]bool), start, end)
-	return edges
-}
-
-func nextJunction2(grid *asciigrid.Grid, junction asciigrid.Index, towards asciigrid.Direction) (asciigrid.Index, int) {
-	p := grid.Pos(junction).Step(towards)
-	cameFrom := towards.Inverse() // If we headed Left, we came from Right, etc.
-	steps := 1
-	for {
-		if grid.Get(p) == 'X' {
-			return grid.Index(p), steps
-		}
-		dirs := []asciigrid.Direction{
-			// Potential optimization: much of the maze consists of long streaks
-			// of walking in the same direction. By checking that direction
-			// first we save a bit of execution time.
-			cameFrom.Inverse(),
-			// At least one of the below is going to be the same as
-			// cameFrom.Inverse(). That's okay -- trying to find it and delete,
-			// or do other tricks, is empirically slower than just checking it
-			// again.
-			asciigrid.Up,
-			asciigrid.Down,
-			asciigrid.Left,
-			asciigrid.Right,
-		}
-		for _, dir := range dirs {
-			if dir == cameFrom {
-				// If we came from Left, we can't go Left, etc.
-				continue
-			}
-			n := p.Step(dir)
-			if !grid.InBounds(n) {
-				continue
-			}
-			c := grid.Get(n)
-			if c == '#' {
-				continue
-			}
-			if c == uphill(dir) {
-				continue
-			}
-			p = n
-			cameFrom = dir.Inverse() // If we headed Left, we came from Right, etc.
-			steps++
-			break
+	for _, src := range compressed.Nodes() {
+		for _, e := range compressed.Edges(src) {
+			g.edges[grid.Index(e.From)] = append(g.edges[grid.Index(e.From)], edge{
+				Src:    grid.Index(e.From),
+				Dst:    grid.Index(e.To),
+				Weight: e.Length,
+			})
 		}
 	}
+	return g, nil
 }
 
 func (g *graph) TopologicalOrder() []asciigrid.Index {
@@ -1,6 +1,7 @@
 package day06
 
 import (
+	"errors"
 	"fmt"
 
 	"go.saser.se/adventofgo/asciigrid"
@@ -16,19 +17,14 @@ func solve(input string, part int) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("parse input as grid: %v", err)
 	}
+	p, ok := asciigrid.Find(g, '^')
+	if !ok {
+		return "", errors.New("no guard found")
+	}
 	start := traveler{
+		Pos:       p,
 		Direction: asciigrid.Up,
 	}
-outer:
-	for row := 0; row < g.NRows(); row++ {
-		for col := 0; col < g.NCols(); col++ {
-			p := asciigrid.Pos{Row: row, Col: col}
-			if g.Get(p) == '^' {
-				start.Pos = p
-				break outer
-			}
-		}
-	}
 	g.Set(start.Pos, '.')
 	path, _ := walk(g, start)
 	if part == 1 {
@@ -17,10 +17,7 @@ func traverse(g *asciigrid.Grid, p asciigrid.Pos, reachableNines map[asciigrid.P
 		nines[p] = struct{}{}
 		return
 	}
-	for _, n := range p.Neighbors4() {
-		if !g.InBounds(n) {
-			continue
-		}
+	for _, n := range g.Neighbors4(p) {
 		if g.Get(n) != g.Get(p)+1 {
 			continue
 		}
@@ -29,18 +26,17 @@ func traverse(g *asciigrid.Grid, p asciigrid.Pos, reachableNines map[asciigrid.P
 	}
 }
 
-func findTrails(g *asciigrid.Grid, p asciigrid.Pos, scores map[asciigrid.Pos]int) (sum int) {
-	defer func() { scores[p] = sum }()
-	if n, ok := scores[p]; ok {
+// findTrails returns the number of distinct trails from p to a 9. The result is
+// memoized in scores, where -1 means that the score hasn't been computed yet.
+func findTrails(g *asciigrid.Grid, p asciigrid.Pos, scores *asciigrid.GridOf[int]) (sum int) {
+	if n := scores.Get(p); n != -1 {
 		return n
 	}
+	defer func() { scores.Set(p, sum) }()
 	if g.Get(p) == '9' {
 		return 1
 	}
-	for _, n := range p.Neighbors4() {
-		if !g.InBounds(n) {
-			continue
-		}
+	for _, n := range g.Neighbors4(p) {
 		if g.Get(n) != g.Get(p)+1 {
 			continue
 		}
@@ -73,7 +69,7 @@ func Part2(input string) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("parse input as grid: %w", err)
 	}
-	scores := make(map[asciigrid.Pos]int, g.NCols()*g.NRows())
+	scores := asciigrid.Make(g.NRows(), g.NCols(), -1)
 	sum := 0
 	for row := 0; row < g.NRows(); row++ {
 		for col := 0; col < g.NCols(); col++ {
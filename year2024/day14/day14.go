@@ -2,10 +2,14 @@ package day14
 
 import (
 	"fmt"
+	"io"
 	"slices"
 	"strconv"
 	"strings"
 	"unicode"
+
+	"go.saser.se/adventofgo/asciigrid"
+	"go.saser.se/adventofgo/geometry"
 )
 
 const (
@@ -13,45 +17,43 @@ const (
 	rows = 103
 )
 
+// robot has a position and a velocity in the puzzle's own coordinates, where X
+// is the column and Y is the row.
 type robot struct {
-	X, Y   int
-	DX, DY int
+	Pos geometry.Pos2
+	// Velocity is the change in position each second.
+	Velocity geometry.Pos2
 }
 
 func parse(line string) (robot, error) {
 	fields := strings.FieldsFunc(line, func(r rune) bool { return !(unicode.IsDigit(r) || r == '-') })
 	var r robot
 	var err error
-	r.X, err = strconv.Atoi(fields[0])
+	r.Pos.X, err = strconv.Atoi(fields[0])
 	if err != nil {
 		return robot{}, fmt.Errorf("parse robot from %q: parse X coordinate: %v", line, err)
 	}
-	r.Y, err = strconv.Atoi(fields[1])
+	r.Pos.Y, err = strconv.Atoi(fields[1])
 	if err != nil {
 		return robot{}, fmt.Errorf("parse robot from %q: parse Y coordinate: %v", line, err)
 	}
-	r.DX, err = strconv.Atoi(fields[2])
+	r.Velocity.X, err = strconv.Atoi(fields[2])
 	if err != nil {
 		return robot{}, fmt.Errorf("parse robot from %q: parse X velocity: %v", line, err)
 	}
-	if r.DX < 0 {
-		r.DX += cols
-	}
-	r.DY, err = strconv.Atoi(fields[3])
+	r.Velocity.Y, err = strconv.Atoi(fields[3])
 	if err != nil {
 		return robot{}, fmt.Errorf("parse robot from %q: parse Y velocity: %v", line, err)
 	}
-	if r.DY < 0 {
-		r.DY += rows
-	}
 	return r, nil
 }
 
+// Step returns r after n seconds. The robots teleport to the other side when
+// they move past an edge.
 func (r robot) Step(n int) robot {
-	r2 := r
-	r2.X = (r.X + (n%cols)*r.DX) % cols
-	r2.Y = (r.Y + (n%rows)*r.DY) % rows
-	return r2
+	p := asciigrid.FromPos2(r.Pos.Add(r.Velocity.Scale(n)))
+	r.Pos = asciigrid.Wrap(p, rows, cols).Pos2()
+	return r
 }
 
 func Part1(input string) (string, error) {
@@ -64,32 +66,29 @@ func Part1(input string) (string, error) {
 		r = r.Step(100)
 		divX := cols / 2
 		divY := rows / 2
-		switch {
-		case r.X < divX && r.Y < divY:
+		switch p := r.Pos; {
+		case p.X < divX && p.Y < divY:
 			upperLeft++
-		case r.X > divX && r.Y < divY:
+		case p.X > divX && p.Y < divY:
 			upperRight++
-		case r.X < divX && r.Y > divY:
+		case p.X < divX && p.Y > divY:
 			lowerLeft++
-		case r.X > divX && r.Y > divY:
+		case p.X > divX && p.Y > divY:
 			lowerRight++
 		}
 	}
 	return fmt.Sprint(upperLeft * upperRight * lowerLeft * lowerRight), nil
 }
 
-func printRobots(robots []robot) {
-	grid := make([][]byte, rows)
-	for y := range grid {
-		grid[y] = slices.Repeat([]byte{'.'}, cols)
-	}
-	for _, r := range robots {
-		grid[r.Y][r.X] = '#'
+// printRobots writes a picture of the area the robots move in to w, where every
+// position with at least one robot is '#'.
+func printRobots(w io.Writer, robots []robot) {
+	ps := make([]asciigrid.Pos, len(robots))
+	for i, r := range robots {
+		ps[i] = asciigrid.FromPos2(r.Pos)
 	}
-	for _, row := range grid {
-		fmt.Println(string(row))
-	}
-	fmt.Println()
+	space := asciigrid.Make(rows, cols, byte('.'))
+	fmt.Fprintln(w, asciigrid.Renderer{}.Render(space, asciigrid.Points(slices.Values(ps), '#', asciigrid.NoColor)))
 }
 
 func hasChristmasTree(robots []robot) bool {
@@ -103,7 +102,7 @@ func hasChristmasTree(robots []robot) bool {
 		grid[y] = slices.Repeat([]byte{'.'}, cols)
 	}
 	for _, r := range robots {
-		grid[r.Y][r.X] = '#'
+		grid[r.Pos.Y][r.Pos.X] = '#'
 	}
 	for _, row := range grid {
 		adjacent := 0
@@ -123,7 +122,9 @@ func hasChristmasTree(robots []robot) bool {
 	return false
 }
 
-func Part2(input string) (string, error) {
+// part2 solves part 2. If debug is not nil, it also writes a picture of the
+// Christmas tree to it.
+func part2(input string, debug io.Writer) (string, error) {
 	var robots []robot
 	for line := range strings.SplitSeq(input, "\n") {
 		r, err := parse(line)
@@ -135,7 +136,9 @@ func Part2(input string) (string, error) {
 	const limit = 100_000
 	for step := range limit {
 		if hasChristmasTree(robots) {
-			// printRobots(robots)
+			if debug != nil {
+				printRobots(debug, robots)
+			}
 			return fmt.Sprint(step), nil
 		}
 		for i := range robots {
@@ -144,3 +147,7 @@ func Part2(input string) (string, error) {
 	}
 	return "", fmt.Errorf("no christmas tree found in %v steps", limit)
 }
+
+func Part2(input string) (string, error) {
+	return part2(input, nil)
+}
@@ -1,8 +1,10 @@
 package day14
 
 import (
+	"strings"
 	"testing"
 
+	"go.saser.se/adventofgo/aocdata"
 	"go.saser.se/adventofgo/aoctest"
 )
 
@@ -14,6 +16,17 @@ func TestPart2(t *testing.T) {
 	aoctest.Test(t, 2024, 14, 2, Part2)
 }
 
+func TestPart2_Picture(t *testing.T) {
+	var sb strings.Builder
+	if _, err := part2(aocdata.InputT(t, 2024, 14), &sb); err != nil {
+		t.Fatal(err)
+	}
+	// The frame around the Christmas tree has a row of 31 robots.
+	if got := sb.String(); !strings.Contains(got, strings.Repeat("#", 31)) {
+		t.Errorf("picture of the robots doesn't contain the Christmas tree:\n%s", got)
+	}
+}
+
 func BenchmarkPart1(b *testing.B) {
 	aoctest.Benchmark(b, 2024, 14, 1, Part1)
 }
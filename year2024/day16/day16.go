@@ -1,11 +1,12 @@
 package day16
 
 import (
+	"errors"
 	"fmt"
-	"slices"
+	"iter"
 
 	"go.saser.se/adventofgo/asciigrid"
-	"go.saser.se/adventofgo/container/priorityqueue"
+	"go.saser.se/adventofgo/search"
 )
 
 func solve(input string, part int) (string, error) {
@@ -14,79 +15,57 @@ func solve(input string, part int) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("parse input as grid: %v", err)
 	}
-	var (
-		start      asciigrid.Pos
-		foundStart bool
-		end        asciigrid.Pos
-		foundEnd   bool
-	)
-	for p, b := range g.All() {
-		if b == 'S' {
-			start = p
-			foundStart = true
-		}
-		if b == 'E' {
-			end = p
-			foundEnd = true
-		}
-		if foundStart && foundEnd {
-			break
-		}
+	start, ok := asciigrid.Find(g, 'S')
+	if !ok {
+		return "", errors.New("no start position found")
+	}
+	end, ok := asciigrid.Find(g, 'E')
+	if !ok {
+		return "", errors.New("no end position found")
 	}
 
-	// Run Dijkstra's algorithm, potentially many times, to find the/all
-	// shortest path(s) from the start to the end.
-	type key struct {
+	// Run Dijkstra's algorithm to find all shortest paths from the start to the
+	// end, which can be reached facing any direction.
+	type state struct {
 		Pos asciigrid.Pos
 		Dir asciigrid.Direction
 	}
-	type state struct {
-		Key   key
-		Cost  int
-		Tiles []asciigrid.Pos
-	}
-	seen := make(map[key]int) // key -> cost of shortest path there
-	pq := priorityqueue.NewFunc(func(x, y state) bool { return x.Cost < y.Cost })
-	pq.Push(state{
-		Key:   key{Pos: start, Dir: asciigrid.Right},
-		Cost:  0,
-		Tiles: []asciigrid.Pos{start},
-	})
-	bestTiles := make(map[asciigrid.Pos]struct{})
-	for pq.Len() > 0 {
-		s := pq.Pop()
-		if s.Key.Pos == end {
-			if part == 1 {
-				return fmt.Sprint(s.Cost), nil
-			}
-			for _, tile := range s.Tiles {
-				bestTiles[tile] = struct{}{}
-			}
-			continue
-		}
-		for _, dir := range []asciigrid.Direction{
-			s.Key.Dir, // keep current direction
-			s.Key.Dir.Turn(asciigrid.TurnClockwise90),        // turn right relative to current direction
-			s.Key.Dir.Turn(asciigrid.TurnCounterClockwise90), // turn left relative to current direction
-		} {
-			// Assumption: next is within bounds due to the surrounding walls.
-			if next := s.Key.Pos.Step(dir); g.Get(next) != '#' {
-				s2 := state{
-					Key:   key{Pos: next, Dir: dir},
-					Cost:  s.Cost + 1,
-					Tiles: append(slices.Clone(s.Tiles), next),
+	neighbors := func(s state) iter.Seq2[state, int] {
+		return func(yield func(state, int) bool) {
+			for _, dir := range []asciigrid.Direction{
+				s.Dir,                                 // keep current direction
+				s.Dir.Turn(asciigrid.TurnClockwise90), // turn right relative to current direction
+				s.Dir.Turn(asciigrid.TurnCounterClockwise90), // turn left relative to current direction
+			} {
+				// Assumption: next is within bounds due to the surrounding walls.
+				next := s.Pos.Step(dir)
+				if g.Get(next) == '#' {
+					continue
 				}
-				if s2.Key.Dir != s.Key.Dir { // we turned
-					s2.Cost += 1000
+				cost := 1
+				if dir != s.Dir { // we turned
+					cost += 1000
 				}
-				if prev, ok := seen[s2.Key]; ok && s2.Cost > prev {
-					continue
+				if !yield(state{Pos: next, Dir: dir}, cost) {
+					return
 				}
-				seen[s2.Key] = s2.Cost
-				pq.Push(s2)
 			}
 		}
 	}
+	isEnd := func(s state) bool { return s.Pos == end }
+	r := search.Dijkstra(state{Pos: start, Dir: asciigrid.Right}, neighbors, isEnd)
+	goal, ok := r.Goal()
+	if !ok {
+		return "", errors.New("no path found from start to end")
+	}
+	if part == 1 {
+		cost, _ := r.Dist(goal)
+		return fmt.Sprint(cost), nil
+	}
+	bestTiles := make(map[asciigrid.Pos]struct{})
+	for s := range r.OnShortestPaths(r.Goals()...) {
+		bestTiles[s.Pos] = struct{}{}
+	}
 	return fmt.Sprint(len(bestTiles)), nil
 }
 
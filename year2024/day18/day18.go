@@ -8,7 +8,7 @@ import (
 	"strings"
 
 	"go.saser.se/adventofgo/asciigrid"
-	"go.saser.se/adventofgo/container/priorityqueue"
+	"go.saser.se/adventofgo/search"
 )
 
 const coordmax = 70
@@ -44,41 +44,23 @@ func Part1(input string) (string, error) {
 		g.Set(p, '#')
 	}
 
-	type state struct {
-		Pos  asciigrid.Pos
-		Cost int
-	}
-	seen := make(map[asciigrid.Pos]struct{})
 	start := asciigrid.Pos{Row: 0, Col: 0}
 	end := asciigrid.Pos{Row: coordmax, Col: coordmax}
-	pq := priorityqueue.NewFunc(func(a, b state) bool { return a.Cost < b.Cost })
-	pq.Push(state{
-		Pos:  start,
-		Cost: 0,
-	})
-	for pq.Len() > 0 {
-		s := pq.Pop()
-		if _, ok := seen[s.Pos]; ok {
-			continue
-		}
-		if s.Pos == end {
-			return fmt.Sprint(s.Cost), nil
-		}
-		seen[s.Pos] = struct{}{}
-		for _, n := range s.Pos.Neighbors4() {
-			if !g.InBounds(n) {
-				continue
-			}
-			if g.Get(n) != '.' {
-				continue
+	neighbors := func(p asciigrid.Pos) iter.Seq[asciigrid.Pos] {
+		return func(yield func(asciigrid.Pos) bool) {
+			for _, n := range g.Neighbors4(p) {
+				if g.Get(n) == '.' && !yield(n) {
+					return
+				}
 			}
-			pq.Push(state{
-				Pos:  n,
-				Cost: s.Cost + 1,
-			})
 		}
 	}
-	return "", errors.New("no solution found")
+	r := search.BFS(start, neighbors, func(p asciigrid.Pos) bool { return p == end })
+	steps, ok := r.Dist(end)
+	if !ok {
+		return "", errors.New("no solution found")
+	}
+	return fmt.Sprint(steps), nil
 }
 
 type connections struct {
@@ -99,7 +81,7 @@ func visit(connectionsByPos map[asciigrid.Pos]connections, start asciigrid.Pos,
 		conn := connectionsByPos[p]
 		modify(&conn)
 		connectionsByPos[p] = conn
-		for _, n := range p.Neighbors8() {
+		for _, n := range p.Neighbors8Seq() {
 			if _, ok := connectionsByPos[n]; !ok {
 				continue
 			}
@@ -115,7 +97,7 @@ func Part2(input string) (string, error) {
 			DownLeft: p.Col == 0 || p.Row == coordmax,
 			UpRight:  p.Row == 0 || p.Col == coordmax,
 		}
-		for _, n := range p.Neighbors8() {
+		for _, n := range p.Neighbors8Seq() {
 			neighborConn, ok := connectionByPos[n]
 			if !ok {
 				continue
@@ -126,7 +108,7 @@ func Part2(input string) (string, error) {
 		if conn.DownLeft && conn.UpRight {
 			return fmt.Sprintf("%d,%d", p.Col, p.Row), nil
 		}
-		for _, n := range p.Neighbors8() {
+		for _, n := range p.Neighbors8Seq() {
 			neighborConn, ok := connectionByPos[n]
 			if !ok {
 				continue
@@ -1,35 +1,53 @@
 package day20
 
 import (
+	"errors"
 	"fmt"
+	"iter"
 
 	"go.saser.se/adventofgo/asciigrid"
-	"go.saser.se/adventofgo/container/priorityqueue"
+	"go.saser.se/adventofgo/search"
 )
 
-func costsFrom(g *asciigrid.Grid, p asciigrid.Pos) map[asciigrid.Pos]int {
-	type state struct {
-		Pos  asciigrid.Pos
-		Cost int
-	}
-	pq := priorityqueue.NewFunc(func(x, y state) bool { return x.Cost < y.Cost })
-	costs := make(map[asciigrid.Pos]int)
-	pq.Push(state{Pos: p})
-	for pq.Len() > 0 {
-		s := pq.Pop()
-		if _, seen := costs[s.Pos]; seen {
-			continue
+// costGrid lets the search record costs straight into a grid, where -1 means
+// unreachable.
+type costGrid struct {
+	g *asciigrid.GridOf[int]
+}
+
+func (c costGrid) Dist(p asciigrid.Pos) (int, bool) {
+	cost := c.g.Get(p)
+	return cost, cost != -1
+}
+
+func (c costGrid) SetDist(p asciigrid.Pos, cost int) {
+	c.g.Set(p, cost)
+}
+
+func (c costGrid) All() iter.Seq2[asciigrid.Pos, int] {
+	return func(yield func(asciigrid.Pos, int) bool) {
+		for p, cost := range c.g.All() {
+			if cost != -1 && !yield(p, cost) {
+				return
+			}
 		}
-		costs[s.Pos] = s.Cost
-		for _, n := range s.Pos.Neighbors4() {
-			if g.Get(n) != '#' {
-				pq.Push(state{
-					Pos:  n,
-					Cost: s.Cost + 1,
-				})
+	}
+}
+
+// costsFrom returns a grid holding the cost of the shortest path from p to each
+// position, or -1 if the position is unreachable.
+func costsFrom(g *asciigrid.Grid, p asciigrid.Pos) *asciigrid.GridOf[int] {
+	neighbors := func(p asciigrid.Pos) iter.Seq[asciigrid.Pos] {
+		return func(yield func(asciigrid.Pos) bool) {
+			for _, n := range p.Neighbors4Seq() {
+				if g.Get(n) != '#' && !yield(n) {
+					return
+				}
 			}
 		}
 	}
+	costs := asciigrid.MakeLike(g, -1)
+	search.BFSInto(p, neighbors, nil, costGrid{costs})
 	return costs
 }
 
@@ -70,14 +88,14 @@ func findSavings(g *asciigrid.Grid, start, end asciigrid.Pos, cheatDuration int)
 	countBySavings := make(map[int]int)
 	fromStart := costsFrom(g, start)
 	toEnd := costsFrom(g, end)
-	baseline := fromStart[end]
-	for cheatStart, initialCost := range fromStart {
-		if initialCost > baseline {
-			break
+	baseline := fromStart.Get(end)
+	for cheatStart, initialCost := range fromStart.All() {
+		if initialCost == -1 || initialCost > baseline {
+			continue
 		}
 		for _, cheatEnd := range reachableWithCheat(g, cheatStart, cheatDuration) {
 			steps := manhattan(cheatStart, cheatEnd)
-			totalCost := initialCost + steps + toEnd[cheatEnd]
+			totalCost := initialCost + steps + toEnd.Get(cheatEnd)
 			savings := baseline - totalCost
 			if savings > 0 {
 				countBySavings[savings]++
@@ -96,17 +114,13 @@ func solve(input string, part int) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("parse input as grid: %v", err)
 	}
-	var start, end asciigrid.Pos // zero-values are never valid positions in this graph.
-	for pos, b := range g.All() {
-		switch b {
-		case 'S':
-			start = pos
-		case 'E':
-			end = pos
-		}
-		if start != (asciigrid.Pos{}) && end != (asciigrid.Pos{}) {
-			break
-		}
+	start, ok := asciigrid.Find(g, 'S')
+	if !ok {
+		return "", errors.New("no start position found")
+	}
+	end, ok := asciigrid.Find(g, 'E')
+	if !ok {
+		return "", errors.New("no end position found")
 	}
 	sum := 0
 	for savings, count := range findSavings(g, start, end, cheatDuration) {
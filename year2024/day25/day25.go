@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"slices"
-	"strings"
 
 	"go.saser.se/adventofgo/asciigrid"
 )
@@ -25,12 +24,12 @@ func solve(input string, part int) (string, error) {
 	if part == 2 {
 		return "", errors.New("unimplemented")
 	}
+	grids, err := asciigrid.ParseBlocks(input)
+	if err != nil {
+		return "", fmt.Errorf("parse input as grids: %v", err)
+	}
 	var locks, keys []pinHeight
-	for fragment := range strings.SplitSeq(input, "\n\n") {
-		g, err := asciigrid.New(fragment)
-		if err != nil {
-			return "", fmt.Errorf("parse fragment as grid: %v", err)
-		}
+	for _, g := range grids {
 		isLock := g.Get(asciigrid.Pos{Row: 0, Col: 0}) == '#'
 		var sig [5]int
 		if isLock {